@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"net/http"
@@ -25,6 +27,7 @@ import (
 func main() {
 	// Parse command line flags
 	configPath := flag.String("config", "./config/config.json", "Path to configuration file")
+	recoverSnapshot := flag.Bool("recover-snapshot", false, "Restore the game state from the newest valid snapshot (json driver)")
 	flag.Parse()
 
 	// Set up logger
@@ -38,9 +41,41 @@ func main() {
 	}
 
 	// Initialize game manager
-	gameManager := game.NewGameManager(cfg)
+	store, err := game.NewStateStore(cfg.Database)
+	if err != nil {
+		logger.Fatal("Failed to open game state store",
+			zap.String("driver", cfg.Database.Driver),
+			zap.Error(err))
+	}
+	defer store.Close()
+
+	if *recoverSnapshot {
+		fileStore, ok := store.(*game.GameStateStorage)
+		if !ok {
+			logger.Fatal("Snapshot recovery is only available for the json driver",
+				zap.String("driver", cfg.Database.Driver))
+		}
+
+		snapshot, err := fileStore.RestoreLatestSnapshot()
+		if err != nil {
+			logger.Fatal("Failed to recover game state from snapshot", zap.Error(err))
+		}
+		logger.Info("Recovered game state from snapshot", zap.String("snapshot", snapshot))
+	}
+
+	gameManager, err := game.NewGameManager(cfg, store)
+	if err != nil {
+		if errors.Is(err, game.ErrCorruptState) {
+			logger.Fatal("Saved game state is corrupt, restart with -recover-snapshot to restore the newest valid snapshot",
+				zap.Error(err))
+		}
+		logger.Fatal("Failed to initialize game manager", zap.Error(err))
+	}
 	gameManager.SetLogger(logger) // Use SetLogger to properly initialize the event system
 
+	// Save changed players in the background from now on
+	gameManager.StartPersistenceSystem()
+
 	// Load game data
 	if err := loadGameData(gameManager, logger); err != nil {
 		logger.Fatal("Failed to load game data", zap.Error(err))
@@ -77,10 +112,33 @@ func main() {
 
 	// Start the event system after everything else is initialized
 	gameManager.StartEventSystem()
-	defer gameManager.StopEventSystem()
+
+	// Start playing for players in auto-pilot mode
+	gameManager.StartAutoPilotSystem()
+
+	// Complete trips as players arrive
+	gameManager.StartTravelSystem()
+
+	// Send the delayed follow-ups of event chains
+	gameManager.StartArcSystem()
+
+	// Cool the subzones down over time
+	gameManager.StartWorldSystem()
+
+	// Move group missions on as their deadlines pass
+	gameManager.StartMissionSystem()
+
+	// Deliver what players missed during their quiet hours
+	gameManager.StartNotificationSystem()
+
+	// Open and close the seasons of the calendar
+	gameManager.StartCalendarSystem()
+
+	// Shake the zones of the city up with world events
+	gameManager.StartWorldEventSystem()
 
 	// Wait for shutdown signal
-	waitForShutdown(logger)
+	waitForShutdown(cfg, logger, server, clientManager, gameManager)
 }
 
 func setupLogger() *zap.Logger {
@@ -102,6 +160,14 @@ func loadGameData(gameManager *game.GameManager, logger *zap.Logger) error {
 	gameManager.LoadCharacters(characters)
 	logger.Info("Loaded characters", zap.Int("count", len(characters)))
 
+	// Load evolutions
+	evolutions, err := dataLoader.LoadEvolutions()
+	if err != nil {
+		return fmt.Errorf("failed to load evolutions: %w", err)
+	}
+	gameManager.LoadEvolutions(evolutions)
+	logger.Info("Loaded evolutions", zap.Int("count", len(evolutions)))
+
 	// Load events
 	events, err := dataLoader.LoadEvents()
 	if err != nil {
@@ -126,6 +192,30 @@ func loadGameData(gameManager *game.GameManager, logger *zap.Logger) error {
 	gameManager.LoadZones(zones)
 	logger.Info("Loaded zones", zap.Int("count", len(zones)))
 
+	// Load group missions
+	missions, err := dataLoader.LoadMissions()
+	if err != nil {
+		return fmt.Errorf("failed to load missions: %w", err)
+	}
+	gameManager.LoadMissions(missions)
+	logger.Info("Loaded missions", zap.Int("count", len(missions)))
+
+	// Load the calendar of seasons
+	seasons, err := dataLoader.LoadSeasons()
+	if err != nil {
+		return fmt.Errorf("failed to load seasons: %w", err)
+	}
+	gameManager.LoadSeasons(seasons)
+	logger.Info("Loaded seasons", zap.Int("count", len(seasons)))
+
+	// Load the world events
+	worldEvents, err := dataLoader.LoadWorldEvents()
+	if err != nil {
+		return fmt.Errorf("failed to load world events: %w", err)
+	}
+	gameManager.LoadWorldEvents(worldEvents)
+	logger.Info("Loaded world events", zap.Int("count", len(worldEvents)))
+
 	return nil
 }
 
@@ -345,7 +435,7 @@ func setupHTTPServer(cfg config.Config, clientManager *whatsapp.ClientManager, q
 	}
 }
 
-func waitForShutdown(logger *zap.Logger) {
+func waitForShutdown(cfg config.Config, logger *zap.Logger, server *http.Server, clientManager *whatsapp.ClientManager, gameManager *game.GameManager) {
 	// Set up channel for shutdown signals
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
@@ -356,4 +446,43 @@ func waitForShutdown(logger *zap.Logger) {
 
 	// Perform cleanup
 	logger.Info("Shutting down")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.Server.ShutdownTimeout)*time.Second)
+	defer cancel()
+
+	// Stop generating new game activity
+	gameManager.StopEventSystem()
+	gameManager.StopAutoPilotSystem()
+	gameManager.StopTravelSystem()
+	gameManager.StopArcSystem()
+	gameManager.StopWorldSystem()
+	gameManager.StopMissionSystem()
+	gameManager.StopNotificationSystem()
+	gameManager.StopCalendarSystem()
+	gameManager.StopWorldEventSystem()
+
+	// Let the messages being handled finish, so no player is left mid-update
+	if err := clientManager.Shutdown(ctx); err != nil {
+		logger.Warn("Message handlers still running at shutdown", zap.Error(err))
+	}
+
+	// Group missions live only in memory, so pay out the ones underway
+	gameManager.EndGroupMissions()
+
+	// Write any changes the persistence system hasn't saved yet
+	gameManager.StopPersistenceSystem()
+	if err := gameManager.Flush(); err != nil {
+		logger.Error("Failed to save game state on shutdown", zap.Error(err))
+	}
+
+	// Close the WhatsApp connections
+	clientManager.DisconnectAll()
+
+	// Close the HTTP server
+	if err := server.Shutdown(ctx); err != nil {
+		logger.Warn("HTTP server did not shut down cleanly", zap.Error(err))
+		server.Close()
+	}
+
+	logger.Info("Shutdown complete")
 }
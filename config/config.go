@@ -38,11 +38,23 @@ type WhatsAppConfig struct {
 
 // DatabaseConfig holds database specific configuration
 type DatabaseConfig struct {
-	// Database driver (sqlite3)
+	// Database driver (sqlite3, json or memory)
 	Driver string `json:"driver"`
 
-	// Database connection string
+	// Database connection string, or the save file path for the json driver
 	DSN string `json:"dsn"`
+
+	// Number of timestamped save snapshots to keep (json driver)
+	SnapshotCount int `json:"snapshot_count"`
+
+	// Minimum time between save snapshots in minutes (json driver)
+	SnapshotInterval int `json:"snapshot_interval"`
+
+	// Time between batched saves of changed players in seconds
+	FlushInterval int `json:"flush_interval"`
+
+	// Number of pending changes that triggers a save before the interval
+	FlushThreshold int `json:"flush_threshold"`
 }
 
 // GameConfig holds game specific configuration
@@ -65,11 +77,115 @@ type GameConfig struct {
 	// Default sub-zone
 	DefaultSubZone string `json:"default_sub_zone"`
 
-	// Time between events in minutes
+	// Time between a player's event checks in minutes
 	EventInterval int `json:"event_interval"`
 
+	// Percentage each player's time to their next event check varies by, either way
+	EventJitter int `json:"event_jitter"`
+
 	// Probability of random events (0-100)
 	RandomEventProbability int `json:"random_event_probability"`
+
+	// Time between auto-pilot turns in minutes
+	AutoPilotInterval int `json:"auto_pilot_interval"`
+
+	// Base travel cost within the same zone, scaled by the destination's reward multiplier
+	TravelCostSameZone int `json:"travel_cost_same_zone"`
+
+	// Base travel cost when crossing zones, scaled by the destination's reward multiplier
+	TravelCostCrossZone int `json:"travel_cost_cross_zone"`
+
+	// Stress added by a trip within the same zone
+	TravelStressSameZone int `json:"travel_stress_same_zone"`
+
+	// Stress added by a trip crossing zones
+	TravelStressCrossZone int `json:"travel_stress_cross_zone"`
+
+	// Travel time within the same zone in game minutes (see clock_speed)
+	TravelTimeSameZone int `json:"travel_time_same_zone"`
+
+	// Travel time when crossing zones in game minutes
+	TravelTimeCrossZone int `json:"travel_time_cross_zone"`
+
+	// Chance of a transit event per destination risk level (0-100)
+	TransitEventChance int `json:"transit_event_chance"`
+
+	// Stress a player in burnout must rest down to before playing normally again
+	BurnoutRecoveryStress int `json:"burnout_recovery_stress"`
+
+	// Actions still allowed during burnout
+	BurnoutRecoveryActions []string `json:"burnout_recovery_actions"`
+
+	// XP needed for each level, starting at level 2
+	LevelXPThresholds []int `json:"level_xp_thresholds"`
+
+	// Attribute points granted on each level-up
+	AttributePointsPerLevel int `json:"attribute_points_per_level"`
+
+	// Highest value an attribute can be raised to
+	MaxAttributeValue int `json:"max_attribute_value"`
+
+	// Probability of an encounter when a predator is around (0-100)
+	EncounterProbability int `json:"encounter_probability"`
+
+	// Minutes a player waits between two interactions of the same kind with other players
+	InteractionCooldown int `json:"interaction_cooldown"`
+
+	// Minutes a challenge, help or payment waits for the other player to accept
+	InteractionTimeout int `json:"interaction_timeout"`
+
+	// Percentage of the victim's money taken by a successful robbery
+	RobberyPercent int `json:"robbery_percent"`
+
+	// Most players a faction can have
+	FactionMaxMembers int `json:"faction_max_members"`
+
+	// Money taken from a faction's bank to claim a subzone
+	FactionClaimCost int `json:"faction_claim_cost"`
+
+	// Added to the reward multiplier of a faction's subzones for its members
+	FactionTerritoryBonus int `json:"faction_territory_bonus"`
+
+	// Control a faction starts with over a subzone it claims (0-100)
+	TerritoryClaimControl int `json:"territory_claim_control"`
+
+	// Control an owner member's action adds to the subzone (0-100)
+	TerritoryControlGain int `json:"territory_control_gain"`
+
+	// Minutes between two decays of the subzones' heat, police and control
+	WorldDecayInterval int `json:"world_decay_interval"`
+
+	// Heat lost by every subzone on each decay
+	HeatDecay int `json:"heat_decay"`
+
+	// Percentage of the gap between heat and police closed on each decay
+	PoliceResponse int `json:"police_response"`
+
+	// Control lost by every claimed subzone on each decay
+	ControlDecay int `json:"control_decay"`
+
+	// Minutes a group mission waits for players to join
+	MissionRecruitTime int `json:"mission_recruit_time"`
+
+	// Minutes the players of a group mission have to vote on each step
+	MissionStepTime int `json:"mission_step_time"`
+
+	// Minutes before the follow-up of an event chain is sent, unless the
+	// outcome sets next_event_delay (0 sends it right away)
+	ChainEventDelay int `json:"chain_event_delay"`
+
+	// Game minutes that pass in each real minute (1 keeps the game clock on
+	// real time)
+	ClockSpeed int `json:"clock_speed"`
+
+	// How many times likelier the events of a season going on are drawn than
+	// regular ones, unless the season sets its own event_weight
+	SeasonEventWeight int `json:"season_event_weight"`
+
+	// Minutes between rolls for a new world event, and the percent chance
+	// each roll starts one
+	WorldEventInterval int `json:"world_event_interval"`
+	WorldEventChance   int `json:"world_event_chance"`
 }
 
 // ServerConfig holds server specific configuration
@@ -79,6 +195,9 @@ type ServerConfig struct {
 
 	// Log level (debug, info, warn, error)
 	LogLevel string `json:"log_level"`
+
+	// Time to wait for in-flight work on shutdown in seconds
+	ShutdownTimeout int `json:"shutdown_timeout"`
 }
 
 // DefaultConfig returns the default configuration
@@ -91,22 +210,61 @@ func DefaultConfig() Config {
 			HostNumber:       "", // Must be set in config file
 		},
 		Database: DatabaseConfig{
-			Driver: "sqlite3",
-			DSN:    "./vida-loka.db",
+			Driver:           "sqlite3",
+			DSN:              "./vida-loka.db",
+			SnapshotCount:    5,
+			SnapshotInterval: 10,
+			FlushInterval:    5,
+			FlushThreshold:   100,
 		},
 		Game: GameConfig{
-			DefaultXP:              0,
-			DefaultMoney:           100,
-			DefaultInfluence:       0,
-			DefaultStress:          0,
-			DefaultZone:            "",
-			DefaultSubZone:         "",
-			EventInterval:          60,
-			RandomEventProbability: 20,
+			DefaultXP:               0,
+			DefaultMoney:            100,
+			DefaultInfluence:        0,
+			DefaultStress:           0,
+			DefaultZone:             "",
+			DefaultSubZone:          "",
+			EventInterval:           60,
+			EventJitter:             25,
+			RandomEventProbability:  20,
+			AutoPilotInterval:       30,
+			TravelCostSameZone:      10,
+			TravelCostCrossZone:     30,
+			TravelStressSameZone:    2,
+			TravelStressCrossZone:   8,
+			TravelTimeSameZone:      5,
+			TravelTimeCrossZone:     20,
+			TransitEventChance:      5,
+			BurnoutRecoveryStress:   60,
+			BurnoutRecoveryActions:  []string{"dormir", "relaxar", "meditar"},
+			LevelXPThresholds:       []int{100, 250, 500, 1000, 1750, 2750, 4000, 5500, 7500},
+			AttributePointsPerLevel: 1,
+			MaxAttributeValue:       10,
+			EncounterProbability:    15,
+			InteractionCooldown:     30,
+			InteractionTimeout:      10,
+			RobberyPercent:          20,
+			FactionMaxMembers:       10,
+			FactionClaimCost:        500,
+			FactionTerritoryBonus:   20,
+			TerritoryClaimControl:   50,
+			TerritoryControlGain:    2,
+			WorldDecayInterval:      10,
+			HeatDecay:               2,
+			PoliceResponse:          25,
+			ControlDecay:            1,
+			MissionRecruitTime:      5,
+			MissionStepTime:         5,
+			ChainEventDelay:         0,
+			ClockSpeed:              1,
+			SeasonEventWeight:       3,
+			WorldEventInterval:      60,
+			WorldEventChance:        20,
 		},
 		Server: ServerConfig{
-			Port:     "8080",
-			LogLevel: "info",
+			Port:            "8080",
+			LogLevel:        "info",
+			ShutdownTimeout: 15,
 		},
 	}
 }
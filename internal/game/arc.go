@@ -0,0 +1,218 @@
+package game
+
+import (
+	"time"
+
+	"github.com/user/vida-loka-strategy/internal/types"
+	"go.uber.org/zap"
+)
+
+// chainEventType marks events only reached as the follow-up of another
+// event, through next_event_id
+const chainEventType = "mission"
+
+// advanceArc moves the player along the arc of an event they just answered.
+// An outcome with a next_event_id starts an arc or takes the current one to
+// that follow-up, which becomes the player's event right away or after its
+// delay; an outcome without one ends the arc. Callers must hold stateLock.
+func (gm *GameManager) advanceArc(player *types.Player, event *types.Event, optionID string, success bool, outcome types.Outcome, now time.Time) {
+	inArc := player.Arc != nil && player.Arc.EventID == event.ID && player.Arc.DueAt.IsZero()
+
+	var next *types.Event
+	if outcome.NextEventID != "" {
+		next = gm.state.Events[outcome.NextEventID]
+		if next == nil {
+			gm.Logger.Warn("Follow-up event not found",
+				zap.String("event_id", event.ID),
+				zap.String("next_event_id", outcome.NextEventID))
+		}
+	}
+
+	if !inArc {
+		// Standalone events leave any arc in progress alone
+		if next == nil {
+			return
+		}
+
+		// A new arc takes over from one still waiting for its follow-up
+		if player.Arc != nil {
+			gm.endArc(player, now)
+		}
+		player.Arc = &types.EventArc{StartEventID: event.ID, StartedAt: now}
+	}
+
+	player.Arc.Path = append(player.Arc.Path, types.ArcStep{EventID: event.ID, OptionID: optionID, Success: success})
+
+	if next == nil {
+		gm.endArc(player, now)
+		return
+	}
+
+	player.Arc.EventID = next.ID
+
+	delay := gm.config.Game.ChainEventDelay
+	if outcome.NextEventDelay > 0 {
+		delay = outcome.NextEventDelay
+	}
+	if delay > 0 {
+		player.Arc.DueAt = now.Add(time.Duration(delay) * time.Minute)
+		return
+	}
+
+	gm.fireArcEvent(player, next, now)
+}
+
+// endArc closes the player's arc and keeps it with their finished ones.
+// Callers must hold stateLock.
+func (gm *GameManager) endArc(player *types.Player, now time.Time) {
+	arc := *player.Arc
+	arc.EventID = ""
+	arc.DueAt = time.Time{}
+	arc.EndedAt = now
+
+	player.CompletedArcs = append(player.CompletedArcs, arc)
+	player.Arc = nil
+
+	gm.Logger.Info("Event arc ended",
+		zap.String("phone_number", player.PhoneNumber),
+		zap.String("start_event_id", arc.StartEventID),
+		zap.Int("steps", len(arc.Path)))
+}
+
+// fireArcEvent makes the follow-up of the player's arc their current event.
+// Callers must hold stateLock.
+func (gm *GameManager) fireArcEvent(player *types.Player, event *types.Event, now time.Time) *types.Event {
+	eventCopy := *event
+	player.CurrentEvent = &eventCopy
+	player.LastEventAt = now
+	player.Arc.DueAt = time.Time{}
+
+	return &eventCopy
+}
+
+// arcFollowUp is a delayed follow-up waiting to be sent to its player,
+// captured under stateLock
+type arcFollowUp struct {
+	phoneNumber string
+	eventID     string
+	message     string
+
+	// Players on auto-pilot get their follow-up answered, not messaged
+	notify bool
+}
+
+// fireDueArcs sets the follow-ups whose delay is over as their players'
+// events. Players busy with another event, on the road, in their quiet hours
+// or with events turned off get theirs once they are free.
+func (gm *GameManager) fireDueArcs(now time.Time) []arcFollowUp {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	var followUps []arcFollowUp
+	for _, player := range gm.state.Players {
+		arc := player.Arc
+		if arc == nil || arc.DueAt.IsZero() || now.Before(arc.DueAt) {
+			continue
+		}
+		if player.CurrentEvent != nil || player.Travel != nil || inQuietHours(player.Notifications, now) ||
+			isMuted(player.Notifications, categoryEvents) {
+			continue
+		}
+
+		event, exists := gm.state.Events[arc.EventID]
+		if !exists {
+			gm.endArc(player, now)
+			gm.markDirty(player)
+			continue
+		}
+
+		fired := gm.fireArcEvent(player, event, now)
+		followUps = append(followUps, arcFollowUp{
+			phoneNumber: player.PhoneNumber,
+			eventID:     fired.ID,
+			message:     "📖 *A HISTÓRIA CONTINUA...* 📖\n\n" + formatEventMessage(fired),
+			notify:      player.Status != "autopilot",
+		})
+
+		// Queue the player for the next save
+		gm.markDirty(player)
+	}
+
+	return followUps
+}
+
+// arcTitle returns the title of the event that started the player's arc in
+// progress, or an empty string. Callers must hold stateLock.
+func (gm *GameManager) arcTitle(player *types.Player) string {
+	if player.Arc == nil {
+		return ""
+	}
+	if event, exists := gm.state.Events[player.Arc.StartEventID]; exists {
+		return eventTitle(event)
+	}
+	return player.Arc.StartEventID
+}
+
+// ArcSystem sends the follow-ups of event chains once their delay is over
+type ArcSystem struct {
+	gameManager *GameManager
+	ticker      *time.Ticker
+	stopChan    chan struct{}
+	doneChan    chan struct{}
+	logger      *zap.Logger
+}
+
+// NewArcSystem creates a new arc system
+func NewArcSystem(gameManager *GameManager, checkInterval time.Duration, logger *zap.Logger) *ArcSystem {
+	return &ArcSystem{
+		gameManager: gameManager,
+		ticker:      time.NewTicker(checkInterval),
+		stopChan:    make(chan struct{}),
+		doneChan:    make(chan struct{}),
+		logger:      logger,
+	}
+}
+
+// Start begins the arc system
+func (as *ArcSystem) Start() {
+	go func() {
+		defer close(as.doneChan)
+
+		for {
+			select {
+			case <-as.ticker.C:
+				as.processFollowUps()
+			case <-as.stopChan:
+				as.ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the arc system, waiting for a running check to finish.
+// Follow-ups still waiting are sent on the next start.
+func (as *ArcSystem) Stop() {
+	close(as.stopChan)
+	<-as.doneChan
+}
+
+// processFollowUps fires the follow-ups that are due and messages each player
+func (as *ArcSystem) processFollowUps() {
+	for _, f := range as.gameManager.fireDueArcs(time.Now()) {
+		as.logger.Info("Event arc continued",
+			zap.String("phone_number", f.phoneNumber),
+			zap.String("event_id", f.eventID))
+
+		// The auto-pilot answers follow-ups in its next turn
+		if !f.notify {
+			continue
+		}
+
+		if err := as.gameManager.sendNotification(f.phoneNumber, categoryEvents, f.message); err != nil {
+			as.logger.Error("Failed to send follow-up event",
+				zap.String("phone_number", f.phoneNumber),
+				zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/user/vida-loka-strategy/internal/types"
+)
+
+// clockEpoch is when the game clock and real time last agreed. The game clock
+// runs clock_speed times faster than real time from there, so it lands on the
+// same game time after a restart.
+var clockEpoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.Local)
+
+// Periods of the game day
+const (
+	periodMorning   = "manha"
+	periodAfternoon = "tarde"
+	periodNight     = "noite"
+	periodDawn      = "madrugada"
+)
+
+// periodNames maps periods to how they are shown
+var periodNames = map[string]string{
+	periodMorning:   "manhã",
+	periodAfternoon: "tarde",
+	periodNight:     "noite",
+	periodDawn:      "madrugada",
+}
+
+// Parts of the week, for day-of-week rules
+const (
+	daysWeekday = "semana"
+	daysWeekend = "fim_de_semana"
+)
+
+// gameWeek is a week of game time in seconds. Only the time of the week
+// matters to the game, so the clock wraps around every game week.
+const gameWeek = int64(7 * 24 * 60 * 60)
+
+// weekdayNames are the short Portuguese names of the days of the week
+var weekdayNames = [...]string{"Dom", "Seg", "Ter", "Qua", "Qui", "Sex", "Sáb"}
+
+// gameTime returns the game clock at a real time. The clock counts whole
+// seconds within a game week from the epoch, a Monday, so scaling the time
+// elapsed can't overflow however fast it runs or however long the server has
+// been up.
+func (gm *GameManager) gameTime(now time.Time) time.Time {
+	speed := gm.config.Game.ClockSpeed
+	if speed <= 1 {
+		return now
+	}
+
+	elapsed := int64(now.Sub(clockEpoch) / time.Second)
+	offset := (elapsed % gameWeek) * (int64(speed) % gameWeek) % gameWeek
+	if offset < 0 {
+		offset += gameWeek
+	}
+	return clockEpoch.Add(time.Duration(offset) * time.Second)
+}
+
+// realDuration returns how long some game minutes take in real time
+func (gm *GameManager) realDuration(gameMinutes int) time.Duration {
+	duration := time.Duration(gameMinutes) * time.Minute
+	if speed := gm.config.Game.ClockSpeed; speed > 1 {
+		duration /= time.Duration(speed)
+	}
+	return duration
+}
+
+// dayPeriod returns the period of the day of a game time: madrugada from
+// midnight, manhã from 6, tarde from noon and noite from 18
+func dayPeriod(t time.Time) string {
+	switch hour := t.Hour(); {
+	case hour < 6:
+		return periodDawn
+	case hour < 12:
+		return periodMorning
+	case hour < 18:
+		return periodAfternoon
+	default:
+		return periodNight
+	}
+}
+
+// weekPart returns whether a game time falls on a weekday or the weekend
+func weekPart(t time.Time) string {
+	if day := t.Weekday(); day == time.Saturday || day == time.Sunday {
+		return daysWeekend
+	}
+	return daysWeekday
+}
+
+// formatGameTime formats a game time for players, e.g. "Sáb 23:40 (noite)"
+func formatGameTime(t time.Time) string {
+	return fmt.Sprintf("%s %s (%s)", weekdayNames[t.Weekday()], t.Format("15:04"), periodNames[dayPeriod(t)])
+}
+
+// timeModifierApplies reports whether a time modifier of an action holds at a
+// game time in a subzone
+func timeModifierApplies(modifier types.TimeModifier, clock time.Time, subZoneID string) bool {
+	if len(modifier.Periods) > 0 && !containsString(modifier.Periods, dayPeriod(clock)) {
+		return false
+	}
+	if modifier.Days != "" && modifier.Days != weekPart(clock) {
+		return false
+	}
+	if len(modifier.SubZones) > 0 && !containsString(modifier.SubZones, subZoneID) {
+		return false
+	}
+	return true
+}
+
+// applyTimeModifiers scales what an action yields by the modifiers that hold
+// at the game time: the positive XP, money and influence by their yield
+// percent, plus any extra stress. It returns the descriptions of the
+// modifiers applied.
+func applyTimeModifiers(outcome *types.Outcome, action *types.Action, clock time.Time, subZoneID string) []string {
+	var notes []string
+	for _, modifier := range action.TimeModifiers {
+		if !timeModifierApplies(modifier, clock, subZoneID) {
+			continue
+		}
+
+		if modifier.YieldPercent > 0 {
+			scale := func(value int) int {
+				if value <= 0 {
+					return value
+				}
+				return value * modifier.YieldPercent / 100
+			}
+			outcome.XPChange = scale(outcome.XPChange)
+			outcome.MoneyChange = scale(outcome.MoneyChange)
+			outcome.InfluenceChange = scale(outcome.InfluenceChange)
+		}
+		outcome.StressChange += modifier.StressChange
+
+		if modifier.Description != "" {
+			notes = append(notes, modifier.Description)
+		}
+	}
+	return notes
+}
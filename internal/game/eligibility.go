@@ -0,0 +1,153 @@
+package game
+
+import (
+	"time"
+
+	"github.com/user/vida-loka-strategy/internal/types"
+)
+
+// eventEligible reports whether an event can happen to the player right now:
+// its XP, money and influence minimums, zone, stress band, heat and police,
+// and the declarative conditions of its conditions block. GenerateEvent and
+// TriggerRandomEvent both draw only from eligible events. Callers must hold
+// stateLock.
+func (gm *GameManager) eventEligible(player *types.Player, event *types.Event, now time.Time) bool {
+	if player.XP < event.MinXP || player.Money < event.MinMoney || player.Influence < event.MinInfluence {
+		return false
+	}
+
+	if len(event.RequiredZone) > 0 && !containsString(event.RequiredZone, player.CurrentZone) {
+		return false
+	}
+
+	// Band events only happen at the matching stress
+	if !eventMatchesStressBand(event, player) {
+		return false
+	}
+
+	// Heat and police events only happen where things are that hot
+	if !eventMatchesSubZone(event, gm.peekSubZoneState(player.CurrentZone, player.CurrentSubZone)) {
+		return false
+	}
+
+	return event.Conditions == nil || conditionsMet(player, event.ID, event.Conditions, now, gm.gameTime(now))
+}
+
+// conditionsMet checks the conditions of an event against the player. Hours,
+// periods and days are read from the game clock; cooldowns run in real time.
+func conditionsMet(player *types.Player, eventID string, c *types.Conditions, now, clock time.Time) bool {
+	if len(c.SubZones) > 0 && !containsString(c.SubZones, player.CurrentSubZone) {
+		return false
+	}
+
+	// Character types match the type or the ID of the player's character
+	if len(c.CharacterTypes) > 0 {
+		if player.CurrentCharacter == nil ||
+			!(containsString(c.CharacterTypes, player.CurrentCharacter.Type) || containsString(c.CharacterTypes, player.CurrentCharacter.ID)) {
+			return false
+		}
+	}
+
+	for attribute, valueRange := range c.Attributes {
+		if !inRange(playerAttribute(player, attribute), valueRange) {
+			return false
+		}
+	}
+
+	if c.Stress != nil && !inRange(player.Stress, *c.Stress) {
+		return false
+	}
+
+	if c.Hours != nil && !inHours(clock.Hour(), *c.Hours) {
+		return false
+	}
+
+	if len(c.Periods) > 0 && !containsString(c.Periods, dayPeriod(clock)) {
+		return false
+	}
+
+	if c.Days != "" && c.Days != weekPart(clock) {
+		return false
+	}
+
+	for _, choice := range c.RequiredChoices {
+		if !hasMadeChoice(player, choice) {
+			return false
+		}
+	}
+
+	for _, choice := range c.ForbiddenChoices {
+		if hasMadeChoice(player, choice) {
+			return false
+		}
+	}
+
+	for _, id := range c.RequiredEvents {
+		if !hasAnsweredEvent(player, id) {
+			return false
+		}
+	}
+
+	for _, id := range c.ForbiddenEvents {
+		if hasAnsweredEvent(player, id) {
+			return false
+		}
+	}
+
+	if c.Cooldown > 0 || c.MaxOccurrences > 0 {
+		occurrences, last := eventOccurrences(player, eventID)
+		if c.MaxOccurrences > 0 && occurrences >= c.MaxOccurrences {
+			return false
+		}
+		if c.Cooldown > 0 && occurrences > 0 && now.Before(last.Add(time.Duration(c.Cooldown)*time.Minute)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hasAnsweredEvent reports whether the player's decision history has a
+// decision on the given event. Interactions and missions count too, as
+// "interaction_<kind>" and "mission_<id>".
+func hasAnsweredEvent(player *types.Player, eventID string) bool {
+	occurrences, _ := eventOccurrences(player, eventID)
+	return occurrences > 0
+}
+
+// eventOccurrences returns how many times the player answered an event and
+// when they last did
+func eventOccurrences(player *types.Player, eventID string) (int, time.Time) {
+	count := 0
+	var last time.Time
+	for _, decision := range player.DecisionHistory {
+		if decision.EventID != eventID {
+			continue
+		}
+		count++
+		if decision.Timestamp.After(last) {
+			last = decision.Timestamp
+		}
+	}
+	return count, last
+}
+
+// inRange reports whether a value is within an inclusive range
+func inRange(value int, r types.ValueRange) bool {
+	if r.Min != nil && value < *r.Min {
+		return false
+	}
+	if r.Max != nil && value > *r.Max {
+		return false
+	}
+	return true
+}
+
+// inHours reports whether an hour of the day is within a range of hours. A
+// range whose min is after its max wraps past midnight, e.g. 22 to 4.
+func inHours(hour int, r types.ValueRange) bool {
+	if r.Min != nil && r.Max != nil && *r.Min > *r.Max {
+		return hour >= *r.Min || hour <= *r.Max
+	}
+	return inRange(hour, r)
+}
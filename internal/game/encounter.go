@@ -0,0 +1,172 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/user/vida-loka-strategy/internal/types"
+)
+
+// encounterEventType marks the event templates used for predator encounters
+const encounterEventType = "encounter"
+
+// predatorPlaceholder is replaced by the predator's name in encounter templates
+const predatorPlaceholder = "{predador}"
+
+// ErrNoPredator is returned by TriggerEncounter when no predator is around
+var ErrNoPredator = errors.New("nenhum predador por perto")
+
+// defaultPredatorStats is the profile of predators that aren't in the
+// character or evolution catalogs
+var defaultPredatorStats = types.Stats{Level: 1, Carisma: 4, Proficiencia: 4, Rede: 4, Moralidade: 4, Resiliencia: 4}
+
+// TriggerEncounter fires an encounter with one of the player's natural
+// predators: a predator type common in their zone or another player of a
+// predator character in the same subzone. It returns ErrNoPredator when
+// there is none around.
+func (gm *GameManager) TriggerEncounter(phoneNumber string) (*types.Event, error) {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	player, exists := gm.state.Players[phoneNumber]
+	if !exists {
+		return nil, errors.New("jogador não encontrado")
+	}
+
+	if player.CurrentCharacter == nil {
+		return nil, errors.New("jogador não selecionou um personagem")
+	}
+
+	predators := gm.nearbyPredators(player)
+	if len(predators) == 0 {
+		return nil, ErrNoPredator
+	}
+
+	var templates []*types.Event
+	for _, event := range gm.state.Events {
+		if event.Type == encounterEventType && eventMatchesStressBand(event, player) {
+			templates = append(templates, event)
+		}
+	}
+	if len(templates) == 0 {
+		return nil, errors.New("nenhum evento de encontro carregado")
+	}
+
+	encounter := predators[rand.Intn(len(predators))]
+	event := newEncounterEvent(templates[rand.Intn(len(templates))], encounter)
+
+	player.CurrentEvent = event
+	player.LastEventAt = time.Now()
+
+	// Queue the player for the next save
+	gm.markDirty(player)
+
+	eventCopy := *event
+	return &eventCopy, nil
+}
+
+// nearbyPredators lists the natural predators around a player. Callers must
+// hold stateLock.
+func (gm *GameManager) nearbyPredators(player *types.Player) []types.Encounter {
+	isPredator := make(map[string]bool)
+	for _, id := range player.CurrentCharacter.NaturalPredators {
+		isPredator[id] = true
+	}
+
+	var predators []types.Encounter
+
+	// Predator types common in the player's zone
+	if zone, exists := gm.state.Zones[player.CurrentZone]; exists {
+		for _, id := range zone.CommonCharacters {
+			if isPredator[id] {
+				predators = append(predators, gm.predatorProfile(id))
+			}
+		}
+	}
+
+	// Players of a predator character in the same subzone
+	for _, other := range gm.state.Players {
+		if other == player || other.CurrentCharacter == nil || other.Stats == nil || other.Travel != nil {
+			continue
+		}
+		if other.CurrentZone != player.CurrentZone || other.CurrentSubZone != player.CurrentSubZone {
+			continue
+		}
+		if isPredator[other.CurrentCharacter.ID] {
+			predators = append(predators, types.Encounter{
+				PredatorID:    other.CurrentCharacter.ID,
+				PredatorName:  other.Name,
+				PredatorPhone: other.PhoneNumber,
+				PredatorStats: *other.Stats,
+			})
+		}
+	}
+
+	return predators
+}
+
+// predatorProfile builds a predator from the character or evolution
+// catalogs, falling back to a default profile. Callers must hold stateLock.
+func (gm *GameManager) predatorProfile(id string) types.Encounter {
+	if character, exists := gm.state.Characters[id]; exists {
+		return types.Encounter{PredatorID: id, PredatorName: character.Name, PredatorStats: *newStats(character)}
+	}
+
+	if evolution, exists := gm.state.Evolutions[id]; exists {
+		return types.Encounter{PredatorID: id, PredatorName: evolution.Name, PredatorStats: *newStats(&evolution.Character)}
+	}
+
+	// e.g. "policial_federal" becomes "Policial Federal"
+	words := strings.Fields(strings.ReplaceAll(id, "_", " "))
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return types.Encounter{PredatorID: id, PredatorName: strings.Join(words, " "), PredatorStats: defaultPredatorStats}
+}
+
+// newEncounterEvent fills an encounter template with the predator
+func newEncounterEvent(template *types.Event, encounter types.Encounter) *types.Event {
+	name := func(text string) string {
+		return strings.ReplaceAll(text, predatorPlaceholder, encounter.PredatorName)
+	}
+
+	event := *template
+	event.Title = name(event.Title)
+	event.Description = name(event.Description)
+	event.Encounter = &encounter
+
+	event.Options = make([]types.EventOption, len(template.Options))
+	for i, option := range template.Options {
+		option.Description = name(option.Description)
+		option.SuccessOutcome.Description = name(option.SuccessOutcome.Description)
+		option.FailureOutcome.Description = name(option.FailureOutcome.Description)
+		event.Options[i] = option
+	}
+
+	return &event
+}
+
+// rollPredator rolls the predator's side of a contested check. Predators have
+// the advantage: they roll two d20 and keep the best.
+func rollPredator(encounter *types.Encounter, attribute string) int {
+	roll := rand.Intn(20) + 1
+	if second := rand.Intn(20) + 1; second > roll {
+		roll = second
+	}
+
+	if value := statsAttribute(&encounter.PredatorStats, attribute); value != nil {
+		roll += *value
+	}
+	return roll
+}
+
+// encounterReport tells a predator player how an encounter with their prey went
+func encounterReport(prey *types.Player, preyWon bool) string {
+	if preyWon {
+		return fmt.Sprintf("🐺 *ENCONTRO* 🐺\n\n*%s* cruzou seu caminho e escapou dessa vez! 😤", prey.Name)
+	}
+	return fmt.Sprintf("🐺 *ENCONTRO* 🐺\n\n*%s* cruzou seu caminho e não teve chance contra você! 😈", prey.Name)
+}
@@ -0,0 +1,206 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/user/vida-loka-strategy/internal/types"
+	"go.uber.org/zap"
+)
+
+// LoadEvolutions loads evolution definitions into the game state
+func (gm *GameManager) LoadEvolutions(evolutions []*types.Evolution) {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	for _, evolution := range evolutions {
+		gm.state.Evolutions[evolution.ID] = evolution
+	}
+
+	// Point evolved players at the freshly loaded evolution definitions
+	for _, player := range gm.state.Players {
+		if player.CurrentCharacter == nil {
+			continue
+		}
+		if _, isBase := gm.state.Characters[player.CurrentCharacter.ID]; isBase {
+			continue
+		}
+		if evolution, exists := gm.state.Evolutions[player.CurrentCharacter.ID]; exists {
+			player.CurrentCharacter = &evolution.Character
+		}
+	}
+}
+
+// GetEvolutionProgress returns the player's progress toward each evolution
+// path of their current character, in the order the character lists them
+func (gm *GameManager) GetEvolutionProgress(phoneNumber string) ([]types.EvolutionProgress, error) {
+	gm.stateLock.RLock()
+	defer gm.stateLock.RUnlock()
+
+	player, exists := gm.state.Players[phoneNumber]
+	if !exists {
+		return nil, errors.New("jogador não encontrado")
+	}
+
+	if player.CurrentCharacter == nil || player.Stats == nil {
+		return nil, errors.New("jogador não selecionou um personagem")
+	}
+
+	var progress []types.EvolutionProgress
+	for _, path := range player.CurrentCharacter.EvolutionPaths {
+		evolution, exists := gm.state.Evolutions[path]
+		if !exists {
+			continue
+		}
+		progress = append(progress, gm.evolutionProgress(player, evolution))
+	}
+
+	return progress, nil
+}
+
+// evolutionProgress checks every requirement of an evolution against the
+// player. Callers must hold stateLock.
+func (gm *GameManager) evolutionProgress(player *types.Player, evolution *types.Evolution) types.EvolutionProgress {
+	req := evolution.Requirements
+	progress := types.EvolutionProgress{Evolution: evolution, Ready: true}
+
+	add := func(met bool, format string, args ...interface{}) {
+		progress.Requirements = append(progress.Requirements, types.RequirementProgress{
+			Description: fmt.Sprintf(format, args...),
+			Met:         met,
+		})
+		if !met {
+			progress.Ready = false
+		}
+	}
+
+	if req.MinLevel > 0 {
+		add(player.Stats.Level >= req.MinLevel, "Nível %d (você: %d)", req.MinLevel, player.Stats.Level)
+	}
+
+	if req.MinMoney > 0 {
+		add(player.Money >= req.MinMoney, "R$ %d,00 (você: R$ %d,00)", req.MinMoney, player.Money)
+	}
+
+	if req.MinInfluence > 0 {
+		add(player.Influence >= req.MinInfluence, "Influência %d (você: %d)", req.MinInfluence, player.Influence)
+	}
+
+	moralidade := player.Stats.Moralidade
+	switch {
+	case req.MinMoralidade > 0 && req.MaxMoralidade > 0:
+		add(moralidade >= req.MinMoralidade && moralidade <= req.MaxMoralidade,
+			"Moralidade entre %d e %d (você: %d)", req.MinMoralidade, req.MaxMoralidade, moralidade)
+	case req.MinMoralidade > 0:
+		add(moralidade >= req.MinMoralidade, "Moralidade %d ou mais (você: %d)", req.MinMoralidade, moralidade)
+	case req.MaxMoralidade > 0:
+		add(moralidade <= req.MaxMoralidade, "Moralidade até %d (você: %d)", req.MaxMoralidade, moralidade)
+	}
+
+	for _, choice := range req.RequiredChoices {
+		add(hasMadeChoice(player, choice), "Já ter escolhido: %s", gm.choiceLabel(choice))
+	}
+
+	for _, choice := range req.ForbiddenChoices {
+		add(!hasMadeChoice(player, choice), "Nunca ter escolhido: %s", gm.choiceLabel(choice))
+	}
+
+	return progress
+}
+
+// hasMadeChoice reports whether the player's decision history has the given
+// choice, an action ID or an event option ID
+func hasMadeChoice(player *types.Player, choice string) bool {
+	for _, decision := range player.DecisionHistory {
+		if decision.Choice == choice {
+			return true
+		}
+	}
+	return false
+}
+
+// choiceLabel describes a choice for players: the action name or the event
+// option description, falling back to the ID. Callers must hold stateLock.
+func (gm *GameManager) choiceLabel(choice string) string {
+	if action, exists := gm.state.Actions[choice]; exists {
+		return "/" + action.Name
+	}
+
+	for _, event := range gm.state.Events {
+		for _, option := range event.Options {
+			if option.ID == choice {
+				return fmt.Sprintf("\"%s\" em %s", option.Description, eventTitle(event))
+			}
+		}
+	}
+
+	return choice
+}
+
+// checkEvolution transforms the player into the first evolution path of their
+// character whose requirements they meet. Callers must hold stateLock.
+func (gm *GameManager) checkEvolution(player *types.Player) {
+	if player.CurrentCharacter == nil || player.Stats == nil {
+		return
+	}
+
+	for _, path := range player.CurrentCharacter.EvolutionPaths {
+		evolution, exists := gm.state.Evolutions[path]
+		if !exists {
+			continue
+		}
+
+		if gm.evolutionProgress(player, evolution).Ready {
+			gm.evolve(player, evolution)
+			return
+		}
+	}
+}
+
+// evolve turns the player into an evolution. The new attribute profile
+// replaces the old character's, keeping the points the player spent on top of
+// it. Callers must hold stateLock.
+func (gm *GameManager) evolve(player *types.Player, evolution *types.Evolution) {
+	previous := player.CurrentCharacter
+	base := newStats(previous)
+	profile := newStats(&evolution.Character)
+
+	for _, name := range attributeNames {
+		spent := *statsAttribute(player.Stats, name) - *statsAttribute(base, name)
+		if spent < 0 {
+			spent = 0
+		}
+
+		value := *statsAttribute(profile, name) + spent
+		if max := gm.config.Game.MaxAttributeValue; max > 0 && value > max {
+			value = max
+		}
+		*statsAttribute(player.Stats, name) = value
+	}
+
+	player.CurrentCharacter = &evolution.Character
+
+	gm.recordDecision(player, types.Decision{
+		ID:        uuid.New().String(),
+		EventID:   "evolution_" + evolution.ID,
+		Choice:    evolution.ID,
+		Timestamp: time.Now(),
+		Outcome:   fmt.Sprintf("%s → %s", previous.Name, evolution.Name),
+	})
+
+	gm.Logger.Info("Player evolved",
+		zap.String("phone_number", player.PhoneNumber),
+		zap.String("from", previous.ID),
+		zap.String("to", evolution.ID))
+
+	message := fmt.Sprintf("🦋 *EVOLUÇÃO* 🦋\n\n"+
+		"%s\n\n"+
+		"Você deixou de ser *%s* e agora é *%s*! 🎉\n\n"+
+		"Use */status* pra ver seus novos atributos.",
+		evolution.Narrative, previous.Name, evolution.Name)
+
+	// Don't hold the state lock while the message goes out
+	go gm.notifyPlayerAbout(player.PhoneNumber, categoryGeneral, message)
+}
@@ -0,0 +1,453 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/user/vida-loka-strategy/internal/types"
+	"go.uber.org/zap"
+)
+
+// Faction roles
+const (
+	factionLeader = "leader"
+	factionMember = "member"
+)
+
+// CreateFaction founds a new faction led by the player
+func (gm *GameManager) CreateFaction(phoneNumber, name string) (*types.Faction, error) {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	player, exists := gm.state.Players[phoneNumber]
+	if !exists {
+		return nil, errors.New("jogador não encontrado")
+	}
+
+	if player.CurrentCharacter == nil {
+		return nil, errors.New("jogador não selecionou um personagem")
+	}
+
+	if gm.playerFaction(phoneNumber) != nil {
+		return nil, errors.New("você já está numa facção")
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("a facção precisa de um nome")
+	}
+	if gm.findFaction(name) != nil {
+		return nil, fmt.Errorf("já existe uma facção chamada %s", name)
+	}
+
+	now := time.Now()
+	faction := &types.Faction{
+		ID:        uuid.New().String(),
+		Name:      name,
+		CreatedAt: now,
+		Members: []types.FactionMember{
+			{PhoneNumber: player.PhoneNumber, Name: player.Name, Role: factionLeader, JoinedAt: now},
+		},
+	}
+	gm.state.Factions[faction.ID] = faction
+
+	// Queue the faction for the next save
+	gm.markFactionDirty(faction.ID)
+
+	gm.Logger.Info("Faction created",
+		zap.String("faction_id", faction.ID),
+		zap.String("name", faction.Name),
+		zap.String("leader", player.PhoneNumber))
+
+	return copyFaction(faction), nil
+}
+
+// InviteToFaction lets the leader invite another player into their faction
+func (gm *GameManager) InviteToFaction(phoneNumber, target string) (*types.Faction, error) {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	faction, err := gm.leaderFaction(phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	other, err := gm.findPlayerByName(target)
+	if err != nil {
+		return nil, err
+	}
+
+	if other.CurrentCharacter == nil {
+		return nil, fmt.Errorf("%s ainda não escolheu um personagem", other.Name)
+	}
+
+	if gm.playerFaction(other.PhoneNumber) != nil {
+		return nil, fmt.Errorf("%s já está numa facção", other.Name)
+	}
+
+	if max := gm.config.Game.FactionMaxMembers; max > 0 && len(faction.Members) >= max {
+		return nil, fmt.Errorf("a facção já tem o máximo de %d membros", max)
+	}
+
+	if !containsString(faction.Invites, other.PhoneNumber) {
+		faction.Invites = append(faction.Invites, other.PhoneNumber)
+		gm.markFactionDirty(faction.ID)
+	}
+
+	go gm.notifyPlayerAbout(other.PhoneNumber, categoryGeneral, fmt.Sprintf("🏴 *CONVITE* 🏴\n\n"+
+		"Você foi chamado pra facção *%s*!\n\n"+
+		"Use */entrar %s* pra fazer parte.", faction.Name, faction.Name))
+
+	return copyFaction(faction), nil
+}
+
+// JoinFaction puts the player in a faction that invited them
+func (gm *GameManager) JoinFaction(phoneNumber, name string) (*types.Faction, error) {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	player, exists := gm.state.Players[phoneNumber]
+	if !exists {
+		return nil, errors.New("jogador não encontrado")
+	}
+
+	if gm.playerFaction(phoneNumber) != nil {
+		return nil, errors.New("você já está numa facção")
+	}
+
+	faction := gm.findFaction(name)
+	if faction == nil {
+		return nil, fmt.Errorf("facção não encontrada: %s", name)
+	}
+
+	if !containsString(faction.Invites, phoneNumber) {
+		return nil, fmt.Errorf("você não foi convidado pra %s", faction.Name)
+	}
+
+	if max := gm.config.Game.FactionMaxMembers; max > 0 && len(faction.Members) >= max {
+		return nil, fmt.Errorf("a facção já tem o máximo de %d membros", max)
+	}
+
+	faction.Invites = removeString(faction.Invites, phoneNumber)
+	faction.Members = append(faction.Members, types.FactionMember{
+		PhoneNumber: player.PhoneNumber,
+		Name:        player.Name,
+		Role:        factionMember,
+		JoinedAt:    time.Now(),
+	})
+
+	// Queue the faction for the next save
+	gm.markFactionDirty(faction.ID)
+
+	gm.notifyFaction(faction, phoneNumber, fmt.Sprintf("🏴 *%s* entrou na facção *%s*! 🤝", player.Name, faction.Name))
+
+	return copyFaction(faction), nil
+}
+
+// LeaveFaction takes the player out of their faction. A leader who leaves
+// hands the faction to the longest-standing member; the last one to leave
+// disbands it, freeing its territories.
+func (gm *GameManager) LeaveFaction(phoneNumber string) (*types.Faction, error) {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	faction := gm.playerFaction(phoneNumber)
+	if faction == nil {
+		return nil, errors.New("você não está numa facção")
+	}
+
+	member := gm.removeMember(faction, phoneNumber)
+	gm.notifyFaction(faction, phoneNumber, fmt.Sprintf("🏴 *%s* saiu da facção *%s*.", member.Name, faction.Name))
+
+	return copyFaction(faction), nil
+}
+
+// KickFromFaction lets the leader remove a member from their faction
+func (gm *GameManager) KickFromFaction(phoneNumber, target string) (*types.Faction, error) {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	faction, err := gm.leaderFaction(phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	other, err := gm.findPlayerByName(target)
+	if err != nil {
+		return nil, err
+	}
+
+	if other.PhoneNumber == phoneNumber {
+		return nil, errors.New("pra sair da facção use /sair")
+	}
+
+	if gm.playerFaction(other.PhoneNumber) != faction {
+		return nil, fmt.Errorf("%s não é da sua facção", other.Name)
+	}
+
+	gm.removeMember(faction, other.PhoneNumber)
+	go gm.notifyPlayerAbout(other.PhoneNumber, categoryGeneral, fmt.Sprintf("🏴 Você foi expulso da facção *%s*. 🚪", faction.Name))
+
+	return copyFaction(faction), nil
+}
+
+// ContributeToFaction moves money from the player to their faction's bank
+func (gm *GameManager) ContributeToFaction(phoneNumber string, amount int) (*types.Faction, error) {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	player, exists := gm.state.Players[phoneNumber]
+	if !exists {
+		return nil, errors.New("jogador não encontrado")
+	}
+
+	faction := gm.playerFaction(phoneNumber)
+	if faction == nil {
+		return nil, errors.New("você não está numa facção")
+	}
+
+	if amount <= 0 {
+		return nil, errors.New("valor inválido")
+	}
+
+	if player.Money < amount {
+		return nil, fmt.Errorf("dinheiro insuficiente: você tem R$ %d,00", player.Money)
+	}
+
+	player.Money -= amount
+	faction.Bank += amount
+	player.LastActiveAt = time.Now()
+
+	// Queue the player and the faction for the next save
+	gm.markDirty(player)
+	gm.markFactionDirty(faction.ID)
+
+	return copyFaction(faction), nil
+}
+
+// ClaimTerritory lets the leader claim the subzone they are in for their
+// faction, paid from its bank. Members get a reward bonus on actions there.
+func (gm *GameManager) ClaimTerritory(phoneNumber string) (*types.Faction, error) {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	faction, err := gm.leaderFaction(phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	player := gm.state.Players[phoneNumber]
+	if player.Travel != nil {
+		return nil, errors.New("você está em trânsito")
+	}
+
+	if owner := gm.territoryOwner(player.CurrentZone, player.CurrentSubZone); owner != nil {
+		if owner == faction {
+			return nil, errors.New("esse lugar já é da sua facção")
+		}
+		return nil, fmt.Errorf("esse lugar já é da facção %s", owner.Name)
+	}
+
+	cost := gm.config.Game.FactionClaimCost
+	if faction.Bank < cost {
+		return nil, fmt.Errorf("o caixa da facção não tem os R$ %d,00 necessários", cost)
+	}
+
+	faction.Bank -= cost
+	faction.Territories = append(faction.Territories, types.Territory{
+		Zone:      player.CurrentZone,
+		SubZone:   player.CurrentSubZone,
+		ClaimedAt: time.Now(),
+	})
+
+	// The faction starts with a partial grip on the place that its members
+	// have to keep up by showing up there
+	state := gm.subZoneState(player.CurrentZone, player.CurrentSubZone)
+	state.Control = clampPercent(gm.config.Game.TerritoryClaimControl)
+	state.UpdatedAt = time.Now()
+
+	// Queue the faction and the subzone for the next save
+	gm.markFactionDirty(faction.ID)
+	gm.markWorldDirty(subZoneKey(player.CurrentZone, player.CurrentSubZone))
+
+	gm.notifyFaction(faction, phoneNumber, fmt.Sprintf("🏴 A facção *%s* agora manda em *%s*! 💪",
+		faction.Name, gm.subZoneDisplayName(player.CurrentZone, player.CurrentSubZone)))
+
+	return copyFaction(faction), nil
+}
+
+// GetPlayerFaction returns the player's faction, or nil if they have none
+func (gm *GameManager) GetPlayerFaction(phoneNumber string) (*types.Faction, error) {
+	gm.stateLock.RLock()
+	defer gm.stateLock.RUnlock()
+
+	if _, exists := gm.state.Players[phoneNumber]; !exists {
+		return nil, errors.New("jogador não encontrado")
+	}
+
+	faction := gm.playerFaction(phoneNumber)
+	if faction == nil {
+		return nil, nil
+	}
+	return copyFaction(faction), nil
+}
+
+// playerFaction returns the faction the player is a member of, or nil.
+// Callers must hold stateLock.
+func (gm *GameManager) playerFaction(phoneNumber string) *types.Faction {
+	for _, faction := range gm.state.Factions {
+		for _, member := range faction.Members {
+			if member.PhoneNumber == phoneNumber {
+				return faction
+			}
+		}
+	}
+	return nil
+}
+
+// leaderFaction returns the faction the player leads, or an error for
+// players who aren't leaders. Callers must hold stateLock.
+func (gm *GameManager) leaderFaction(phoneNumber string) (*types.Faction, error) {
+	if _, exists := gm.state.Players[phoneNumber]; !exists {
+		return nil, errors.New("jogador não encontrado")
+	}
+
+	faction := gm.playerFaction(phoneNumber)
+	if faction == nil {
+		return nil, errors.New("você não está numa facção")
+	}
+
+	for _, member := range faction.Members {
+		if member.PhoneNumber == phoneNumber && member.Role != factionLeader {
+			return nil, errors.New("só o líder da facção pode fazer isso")
+		}
+	}
+
+	return faction, nil
+}
+
+// findFaction finds a faction by name, ignoring case. Callers must hold stateLock.
+func (gm *GameManager) findFaction(name string) *types.Faction {
+	name = strings.TrimSpace(name)
+	for _, faction := range gm.state.Factions {
+		if strings.EqualFold(faction.Name, name) {
+			return faction
+		}
+	}
+	return nil
+}
+
+// territoryOwner returns the faction that claimed a subzone, or nil.
+// Callers must hold stateLock.
+func (gm *GameManager) territoryOwner(zoneID, subZoneID string) *types.Faction {
+	for _, faction := range gm.state.Factions {
+		for _, territory := range faction.Territories {
+			if territory.Zone == zoneID && territory.SubZone == subZoneID {
+				return faction
+			}
+		}
+	}
+	return nil
+}
+
+// factionTerritoryBonus returns the reward multiplier bonus the player gets
+// in a subzone: the configured bonus when their faction claimed it, 0
+// otherwise. Callers must hold stateLock.
+func (gm *GameManager) factionTerritoryBonus(phoneNumber, zoneID, subZoneID string) int {
+	faction := gm.playerFaction(phoneNumber)
+	if faction == nil || gm.territoryOwner(zoneID, subZoneID) != faction {
+		return 0
+	}
+	return gm.config.Game.FactionTerritoryBonus
+}
+
+// dropTerritory takes a subzone out of a faction's territories. Callers must
+// hold stateLock.
+func (gm *GameManager) dropTerritory(faction *types.Faction, zoneID, subZoneID string) {
+	var kept []types.Territory
+	for _, territory := range faction.Territories {
+		if territory.Zone != zoneID || territory.SubZone != subZoneID {
+			kept = append(kept, territory)
+		}
+	}
+	faction.Territories = kept
+
+	// Queue the faction for the next save
+	gm.markFactionDirty(faction.ID)
+}
+
+// removeMember takes a player out of a faction, handing leadership over or
+// disbanding the faction as needed, and returns the removed member. Callers
+// must hold stateLock.
+func (gm *GameManager) removeMember(faction *types.Faction, phoneNumber string) types.FactionMember {
+	var removed types.FactionMember
+	members := faction.Members[:0]
+	for _, member := range faction.Members {
+		if member.PhoneNumber == phoneNumber {
+			removed = member
+			continue
+		}
+		members = append(members, member)
+	}
+	faction.Members = members
+
+	if len(faction.Members) == 0 {
+		delete(gm.state.Factions, faction.ID)
+		gm.Logger.Info("Faction disbanded",
+			zap.String("faction_id", faction.ID),
+			zap.String("name", faction.Name))
+	} else if removed.Role == factionLeader {
+		// Members are kept in joining order
+		faction.Members[0].Role = factionLeader
+		go gm.notifyPlayerAbout(faction.Members[0].PhoneNumber, categoryGeneral,
+			fmt.Sprintf("👑 Você agora é o líder da facção *%s*!", faction.Name))
+	}
+
+	// Queue the faction for the next save (or deletion)
+	gm.markFactionDirty(faction.ID)
+
+	return removed
+}
+
+// notifyFaction messages every member of a faction except one. Callers must
+// hold stateLock; the messages go out after it is released.
+func (gm *GameManager) notifyFaction(faction *types.Faction, except, message string) {
+	for _, member := range faction.Members {
+		if member.PhoneNumber != except {
+			go gm.notifyPlayerAbout(member.PhoneNumber, categoryGeneral, message)
+		}
+	}
+}
+
+// copyFaction returns a copy of a faction that doesn't share its slices
+func copyFaction(faction *types.Faction) *types.Faction {
+	factionCopy := *faction
+	factionCopy.Members = append([]types.FactionMember(nil), faction.Members...)
+	factionCopy.Territories = append([]types.Territory(nil), faction.Territories...)
+	factionCopy.Invites = append([]string(nil), faction.Invites...)
+	return &factionCopy
+}
+
+// containsString reports whether a slice has the given value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// removeString returns the slice without the given value
+func removeString(values []string, value string) []string {
+	var kept []string
+	for _, v := range values {
+		if v != value {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
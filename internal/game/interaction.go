@@ -0,0 +1,409 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/user/vida-loka-strategy/internal/interfaces"
+	"github.com/user/vida-loka-strategy/internal/types"
+	"go.uber.org/zap"
+)
+
+// ErrNoPendingInteraction is returned by RespondToInteraction when no invite
+// is waiting for the player
+var ErrNoPendingInteraction = interfaces.ErrNoPendingInteraction
+
+// interactionKind describes a kind of player-vs-player interaction
+type interactionKind struct {
+	// ID used by commands and recorded as the initiator's choice
+	ID string
+
+	// Display name
+	Name string
+
+	// Attribute rolled by the player who starts the interaction
+	Attribute string
+
+	// Attribute rolled by the other player
+	TargetAttribute string
+
+	// Whether the other player has to accept before the roll
+	Consent bool
+
+	// Whether both players have to be in the same subzone
+	SameSubZone bool
+
+	// Heat left in the subzone where the interaction happens
+	Heat int
+}
+
+// interactionKinds lists the interactions players can start with each other
+var interactionKinds = map[string]interactionKind{
+	"desafiar": {ID: "desafiar", Name: "Desafio", Attribute: "proficiencia", TargetAttribute: "proficiencia", Consent: true, SameSubZone: true},
+	"roubar":   {ID: "roubar", Name: "Roubo", Attribute: "proficiencia", TargetAttribute: "rede", SameSubZone: true, Heat: 10},
+	"ajudar":   {ID: "ajudar", Name: "Ajuda", Attribute: "carisma", TargetAttribute: "resiliencia", Consent: true, SameSubZone: true, Heat: -5},
+	"pagar":    {ID: "pagar", Name: "Pagamento", Attribute: "carisma", TargetAttribute: "carisma", Consent: true},
+}
+
+// interactionTargetChoice is the choice recorded for the player on the
+// receiving end of an interaction
+const interactionTargetChoice = "alvo"
+
+// StartInteraction starts an interaction of the given kind with the player
+// named by target (a name, optionally with @, or a phone number). Robberies
+// are resolved right away; the other kinds wait for the target to accept them
+// with RespondToInteraction and come back without a Result.
+func (gm *GameManager) StartInteraction(phoneNumber, kind, target string, amount int) (*types.Interaction, error) {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	k, exists := interactionKinds[kind]
+	if !exists {
+		return nil, fmt.Errorf("interação inválida: %s", kind)
+	}
+
+	player, exists := gm.state.Players[phoneNumber]
+	if !exists {
+		return nil, errors.New("jogador não encontrado")
+	}
+
+	if err := checkCanInteract(player, "você"); err != nil {
+		return nil, err
+	}
+
+	other, err := gm.findPlayerByName(target)
+	if err != nil {
+		return nil, err
+	}
+
+	if other == player {
+		return nil, errors.New("você não pode interagir com você mesmo")
+	}
+
+	if err := checkCanInteract(other, other.Name); err != nil {
+		return nil, err
+	}
+
+	if k.SameSubZone && (other.CurrentZone != player.CurrentZone || other.CurrentSubZone != player.CurrentSubZone) {
+		return nil, fmt.Errorf("%s não está no mesmo lugar que você", other.Name)
+	}
+
+	if k.ID == "pagar" {
+		if amount <= 0 {
+			return nil, errors.New("valor inválido")
+		}
+		if player.Money < amount {
+			return nil, fmt.Errorf("dinheiro insuficiente: você tem R$ %d,00", player.Money)
+		}
+	}
+
+	if wait := gm.interactionCooldown(player, k.ID); wait > 0 {
+		return nil, fmt.Errorf("espere %d min para outro %s", int(wait.Minutes())+1, strings.ToLower(k.Name))
+	}
+
+	now := time.Now()
+	interaction := &types.Interaction{
+		ID:        uuid.New().String(),
+		Kind:      k.ID,
+		FromPhone: player.PhoneNumber,
+		FromName:  player.Name,
+		ToPhone:   other.PhoneNumber,
+		ToName:    other.Name,
+		Amount:    amount,
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Duration(gm.config.Game.InteractionTimeout) * time.Minute),
+	}
+
+	if !k.Consent {
+		gm.resolveInteraction(interaction, player, other)
+		go gm.notifyPlayerAbout(other.PhoneNumber, categoryPvP, interactionReport(interaction, other.PhoneNumber))
+
+		interactionCopy := *interaction
+		return &interactionCopy, nil
+	}
+
+	if pending := gm.pendingInteraction(other.PhoneNumber, now); pending != nil {
+		return nil, fmt.Errorf("%s já tem um convite pendente, tente mais tarde", other.Name)
+	}
+	gm.interactions[other.PhoneNumber] = interaction
+
+	go gm.notifyPlayerAbout(other.PhoneNumber, categoryPvP, interactionInvite(interaction, gm.config.Game.InteractionTimeout))
+
+	interactionCopy := *interaction
+	return &interactionCopy, nil
+}
+
+// RespondToInteraction accepts or declines the interaction waiting for the
+// player's consent. An accepted interaction is resolved and returned with its
+// Result; either way the player who started it is told.
+func (gm *GameManager) RespondToInteraction(phoneNumber string, accept bool) (*types.Interaction, error) {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	player, exists := gm.state.Players[phoneNumber]
+	if !exists {
+		return nil, errors.New("jogador não encontrado")
+	}
+
+	interaction := gm.pendingInteraction(phoneNumber, time.Now())
+	if interaction == nil {
+		return nil, ErrNoPendingInteraction
+	}
+	delete(gm.interactions, phoneNumber)
+
+	if !accept {
+		go gm.notifyPlayerAbout(interaction.FromPhone, categoryPvP, fmt.Sprintf("🙅 *%s* recusou seu convite (%s).",
+			player.Name, strings.ToLower(interactionKinds[interaction.Kind].Name)))
+
+		interactionCopy := *interaction
+		return &interactionCopy, nil
+	}
+
+	// Things may have changed while the invite waited
+	from, exists := gm.state.Players[interaction.FromPhone]
+	if !exists {
+		return nil, errors.New("quem te convidou não está mais no jogo")
+	}
+	if err := checkCanInteract(from, from.Name); err != nil {
+		return nil, err
+	}
+	if err := checkCanInteract(player, "você"); err != nil {
+		return nil, err
+	}
+	if interactionKinds[interaction.Kind].SameSubZone &&
+		(from.CurrentZone != player.CurrentZone || from.CurrentSubZone != player.CurrentSubZone) {
+		return nil, fmt.Errorf("%s não está mais no mesmo lugar que você", from.Name)
+	}
+	if interaction.Kind == "pagar" && from.Money < interaction.Amount {
+		return nil, fmt.Errorf("%s não tem mais esse dinheiro", from.Name)
+	}
+
+	gm.resolveInteraction(interaction, from, player)
+	go gm.notifyPlayerAbout(from.PhoneNumber, categoryPvP, interactionReport(interaction, from.PhoneNumber))
+
+	interactionCopy := *interaction
+	return &interactionCopy, nil
+}
+
+// checkCanInteract returns why a player can't take part in an interaction,
+// or nil if they can
+func checkCanInteract(player *types.Player, who string) error {
+	if player.CurrentCharacter == nil || player.Stats == nil {
+		return fmt.Errorf("%s ainda não escolheu um personagem", who)
+	}
+	if player.Travel != nil {
+		return fmt.Errorf("%s está em trânsito", who)
+	}
+	if player.Burnout {
+		return fmt.Errorf("%s está em burnout", who)
+	}
+	return nil
+}
+
+// findPlayerByName finds a player by name, ignoring case and a leading @, or
+// by phone number. Callers must hold stateLock.
+func (gm *GameManager) findPlayerByName(query string) (*types.Player, error) {
+	query = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(query), "@"))
+	if query == "" {
+		return nil, errors.New("diga com quem")
+	}
+
+	if player, exists := gm.state.Players[query]; exists {
+		return player, nil
+	}
+
+	var found *types.Player
+	for _, player := range gm.state.Players {
+		if !strings.EqualFold(player.Name, query) {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("tem mais de um jogador chamado %s", query)
+		}
+		found = player
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("jogador não encontrado: %s", query)
+	}
+	return found, nil
+}
+
+// pendingInteraction returns the interaction waiting for the player's
+// consent, dropping it once expired. Callers must hold stateLock.
+func (gm *GameManager) pendingInteraction(phoneNumber string, now time.Time) *types.Interaction {
+	interaction, exists := gm.interactions[phoneNumber]
+	if !exists {
+		return nil
+	}
+	if now.After(interaction.ExpiresAt) {
+		delete(gm.interactions, phoneNumber)
+		return nil
+	}
+	return interaction
+}
+
+// interactionCooldown returns how long the player still has to wait before
+// starting another interaction of the kind, going by their decision history
+func (gm *GameManager) interactionCooldown(player *types.Player, kind string) time.Duration {
+	cooldown := time.Duration(gm.config.Game.InteractionCooldown) * time.Minute
+	for i := len(player.DecisionHistory) - 1; i >= 0; i-- {
+		decision := player.DecisionHistory[i]
+		if decision.EventID == "interaction_"+kind && decision.Choice == kind {
+			return time.Until(decision.Timestamp.Add(cooldown))
+		}
+	}
+	return 0
+}
+
+// resolveInteraction makes the opposed roll of an interaction, applies the
+// outcome to both players and records it in both decision histories. Ties go
+// to the player on the receiving end. Callers must hold stateLock.
+func (gm *GameManager) resolveInteraction(interaction *types.Interaction, from, to *types.Player) {
+	k := interactionKinds[interaction.Kind]
+
+	result := &types.InteractionResult{
+		FromRoll: rand.Intn(20) + 1 + playerAttribute(from, k.Attribute) + playerStressBand(from).RollPenalty,
+		ToRoll:   rand.Intn(20) + 1 + playerAttribute(to, k.TargetAttribute) + playerStressBand(to).RollPenalty,
+	}
+	result.Success = result.FromRoll > result.ToRoll
+	result.FromOutcome, result.ToOutcome = gm.interactionOutcomes(interaction, to, result.Success)
+	interaction.Result = result
+
+	now := time.Now()
+	summary := fmt.Sprintf("%s %s → %s: %d x %d, Success: %t",
+		k.Name, from.Name, to.Name, result.FromRoll, result.ToRoll, result.Success)
+
+	gm.applyInteractionOutcome(from, interaction, k.ID, result.FromOutcome, summary, now)
+	gm.applyInteractionOutcome(to, interaction, interactionTargetChoice, result.ToOutcome, summary, now)
+
+	// Robberies heat the place up and help calms it down
+	if k.SameSubZone {
+		gm.recordSubZoneImpact(from, k.Heat)
+	}
+
+	gm.Logger.Info("Interaction resolved",
+		zap.String("kind", k.ID),
+		zap.String("from", from.PhoneNumber),
+		zap.String("to", to.PhoneNumber),
+		zap.Int("from_roll", result.FromRoll),
+		zap.Int("to_roll", result.ToRoll),
+		zap.Bool("success", result.Success))
+}
+
+// interactionOutcomes returns what an interaction does to the player who
+// started it and to the other player
+func (gm *GameManager) interactionOutcomes(interaction *types.Interaction, to *types.Player, success bool) (types.Outcome, types.Outcome) {
+	from, target := interaction.FromName, interaction.ToName
+
+	switch interaction.Kind {
+	case "desafiar":
+		if success {
+			return types.Outcome{Description: fmt.Sprintf("Você ganhou o desafio contra %s! 🏆", target), XPChange: 15, InfluenceChange: 5, StressChange: -5},
+				types.Outcome{Description: fmt.Sprintf("Você perdeu o desafio pra %s. 😓", from), XPChange: 5, InfluenceChange: -3, StressChange: 8}
+		}
+		return types.Outcome{Description: fmt.Sprintf("Você perdeu o desafio pra %s. 😓", target), XPChange: 5, InfluenceChange: -3, StressChange: 8},
+			types.Outcome{Description: fmt.Sprintf("Você ganhou o desafio contra %s! 🏆", from), XPChange: 15, InfluenceChange: 5, StressChange: -5}
+
+	case "roubar":
+		if success {
+			// Players in debt have nothing to take
+			stolen := max(0, to.Money*gm.config.Game.RobberyPercent/100)
+			if stolen == 0 {
+				return types.Outcome{Description: fmt.Sprintf("Você encurralou %s, mas não tinha nada pra levar. 🤷", target), XPChange: 3, StressChange: 5},
+					types.Outcome{Description: fmt.Sprintf("%s tentou te roubar, mas seu bolso tava vazio. 😅", from), StressChange: 5}
+			}
+			return types.Outcome{Description: fmt.Sprintf("Você limpou %s e levou R$ %d,00! 💸", target, stolen), XPChange: 10, MoneyChange: stolen, StressChange: 5},
+				types.Outcome{Description: fmt.Sprintf("%s te roubou R$ %d,00! 😱", from, stolen), MoneyChange: -stolen, StressChange: 10}
+		}
+		return types.Outcome{Description: fmt.Sprintf("%s percebeu e você saiu correndo de mãos vazias. 🏃", target), XPChange: 2, InfluenceChange: -5, StressChange: 10},
+			types.Outcome{Description: fmt.Sprintf("%s tentou te roubar, mas você não deu mole! 💪", from), XPChange: 5, InfluenceChange: 2}
+
+	case "ajudar":
+		if success {
+			return types.Outcome{Description: fmt.Sprintf("Sua ajuda fez a diferença pra %s. 🤝", target), XPChange: 10, InfluenceChange: 3, StressChange: -3},
+				types.Outcome{Description: fmt.Sprintf("%s te deu uma força e tanto! 🙏", from), XPChange: 5, StressChange: -10}
+		}
+		return types.Outcome{Description: fmt.Sprintf("%s até aceitou, mas não ajudou muito. 🤷", target), XPChange: 3},
+			types.Outcome{Description: fmt.Sprintf("%s tentou ajudar, mas você não deixou. 😤", from), XPChange: 2, StressChange: -3}
+
+	case "pagar":
+		if success {
+			return types.Outcome{Description: fmt.Sprintf("Você pagou R$ %d,00 a %s e ainda saiu por cima no acordo. 🤑", interaction.Amount, target), MoneyChange: -interaction.Amount, InfluenceChange: 3},
+				types.Outcome{Description: fmt.Sprintf("%s te pagou R$ %d,00. 💰", from, interaction.Amount), MoneyChange: interaction.Amount}
+		}
+		return types.Outcome{Description: fmt.Sprintf("Você pagou R$ %d,00 a %s. 💸", interaction.Amount, target), MoneyChange: -interaction.Amount},
+			types.Outcome{Description: fmt.Sprintf("%s te pagou R$ %d,00 e você ainda saiu por cima no acordo. 🤑", from, interaction.Amount), MoneyChange: interaction.Amount, InfluenceChange: 3}
+	}
+
+	return types.Outcome{}, types.Outcome{}
+}
+
+// applyInteractionOutcome applies one side of an interaction to a player and
+// records it in their decision history. Callers must hold stateLock.
+func (gm *GameManager) applyInteractionOutcome(player *types.Player, interaction *types.Interaction, choice string, outcome types.Outcome, summary string, now time.Time) {
+	player.XP += outcome.XPChange
+	player.Money += outcome.MoneyChange
+	player.Influence += outcome.InfluenceChange
+	player.Stress += outcome.StressChange
+
+	// Keep stress within bounds and burn out (or recover) the player
+	gm.updateBurnout(player)
+
+	// New XP may mean a new level
+	gm.checkLevelUp(player)
+
+	// Progress may also complete an evolution path
+	gm.checkEvolution(player)
+
+	player.LastActiveAt = now
+
+	gm.recordDecision(player, types.Decision{
+		ID:              uuid.New().String(),
+		EventID:         "interaction_" + interaction.Kind,
+		Choice:          choice,
+		Timestamp:       now,
+		Outcome:         summary,
+		XPChange:        outcome.XPChange,
+		MoneyChange:     outcome.MoneyChange,
+		InfluenceChange: outcome.InfluenceChange,
+		StressChange:    outcome.StressChange,
+	})
+
+	// Queue the player for the next save
+	gm.markDirty(player)
+}
+
+// interactionInvite asks a player to accept an interaction
+func interactionInvite(interaction *types.Interaction, timeout int) string {
+	var invite string
+	switch interaction.Kind {
+	case "desafiar":
+		invite = fmt.Sprintf("⚔️ *DESAFIO* ⚔️\n\n*%s* te desafiou pra ver quem é o mais brabo!", interaction.FromName)
+	case "ajudar":
+		invite = fmt.Sprintf("🤝 *AJUDA* 🤝\n\n*%s* quer te dar uma força.", interaction.FromName)
+	case "pagar":
+		invite = fmt.Sprintf("💰 *PAGAMENTO* 💰\n\n*%s* quer te pagar *R$ %d,00*.", interaction.FromName, interaction.Amount)
+	}
+
+	return fmt.Sprintf("%s\n\nResponda com */aceitar* ou */recusar* (vale por %d min).", invite, timeout)
+}
+
+// interactionReport tells a player how an interaction they took part in
+// went, from their side
+func interactionReport(interaction *types.Interaction, phoneNumber string) string {
+	result := interaction.Result
+	k := interactionKinds[interaction.Kind]
+
+	outcome, mine, theirs := result.FromOutcome, result.FromRoll, result.ToRoll
+	if phoneNumber == interaction.ToPhone {
+		outcome, mine, theirs = result.ToOutcome, result.ToRoll, result.FromRoll
+	}
+
+	return fmt.Sprintf("🎲 *%s* 🎲\n\n%s\n\n🎲 Rolagem: %d x %d%s",
+		strings.ToUpper(k.Name), outcome.Description, mine, theirs, formatOutcomeChanges(&outcome))
+}
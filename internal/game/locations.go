@@ -0,0 +1,174 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/user/vida-loka-strategy/internal/types"
+)
+
+// accentReplacer strips the Portuguese accents players often leave out
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a",
+	"é", "e", "è", "e", "ê", "e",
+	"í", "i", "ì", "i", "î", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c",
+)
+
+// normalizeLocationName lowercases a name, strips accents and turns
+// underscores and repeated spaces into single spaces, so "Méier", "meier" and
+// "complexo_alemao" compare like their catalog counterparts
+func normalizeLocationName(name string) string {
+	name = accentReplacer.Replace(strings.ToLower(name))
+	name = strings.ReplaceAll(name, "_", " ")
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// locationMatches reports whether query names the location with the given ID
+// and display name, exactly or as a prefix of any of its words
+func locationMatches(query, id, name string) (exact bool, partial bool) {
+	candidates := []string{normalizeLocationName(id), normalizeLocationName(name)}
+
+	for _, candidate := range candidates {
+		if query == candidate {
+			return true, true
+		}
+	}
+
+	for _, candidate := range candidates {
+		// "copa" finds Copacabana, "alemao" finds Complexo do Alemão
+		if strings.HasPrefix(candidate, query) {
+			return false, true
+		}
+		for _, word := range strings.Fields(candidate) {
+			if len(query) >= 3 && strings.HasPrefix(word, query) {
+				return false, true
+			}
+		}
+	}
+
+	return false, false
+}
+
+// GetZones returns every loaded zone, sorted by ID
+func (gm *GameManager) GetZones() []*types.Zone {
+	gm.stateLock.RLock()
+	defer gm.stateLock.RUnlock()
+
+	return gm.sortedZones()
+}
+
+// sortedZones returns the zones sorted by ID. Callers must hold stateLock.
+func (gm *GameManager) sortedZones() []*types.Zone {
+	zones := make([]*types.Zone, 0, len(gm.state.Zones))
+	for _, zone := range gm.state.Zones {
+		zones = append(zones, zone)
+	}
+
+	sort.Slice(zones, func(i, j int) bool {
+		return zones[i].ID < zones[j].ID
+	})
+
+	return zones
+}
+
+// FindLocation resolves a place typed by a player against the zone catalog.
+// Accents, case and underscores are ignored and partial names are accepted
+// when they match a single place. A subzone match returns its zone and the
+// subzone; a zone match returns the zone and a nil subzone.
+func (gm *GameManager) FindLocation(query string) (*types.Zone, *types.SubZone, error) {
+	gm.stateLock.RLock()
+	defer gm.stateLock.RUnlock()
+
+	query = normalizeLocationName(query)
+	if query == "" {
+		return nil, nil, errors.New("nenhum lugar informado")
+	}
+
+	type match struct {
+		zone    *types.Zone
+		subZone *types.SubZone
+	}
+
+	var exact, partial []match
+	for _, zone := range gm.sortedZones() {
+		for i := range zone.SubZones {
+			subZone := &zone.SubZones[i]
+			isExact, isPartial := locationMatches(query, subZone.ID, subZone.Name)
+			if isExact {
+				exact = append(exact, match{zone, subZone})
+			} else if isPartial {
+				partial = append(partial, match{zone, subZone})
+			}
+		}
+
+		isExact, isPartial := locationMatches(query, zone.ID, zone.Name)
+		if isExact {
+			exact = append(exact, match{zone, nil})
+		} else if isPartial {
+			partial = append(partial, match{zone, nil})
+		}
+	}
+
+	// Exact names win, so "tijuca" is Tijuca and not Barra da Tijuca
+	matches := exact
+	if len(matches) == 0 {
+		matches = partial
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, nil, fmt.Errorf("lugar não encontrado: %s", query)
+	case 1:
+		return matches[0].zone, matches[0].subZone, nil
+	}
+
+	names := make([]string, len(matches))
+	for i, m := range matches {
+		if m.subZone != nil {
+			names[i] = m.subZone.Name
+		} else {
+			names[i] = m.zone.Name
+		}
+	}
+	return nil, nil, fmt.Errorf("mais de um lugar combina com \"%s\": %s", query, strings.Join(names, ", "))
+}
+
+// startingLocation picks where a new character starts: the first zone (by ID)
+// that lists the character among its common characters, then the configured
+// default zone, then the first zone in the catalog. Callers must hold stateLock.
+func (gm *GameManager) startingLocation(character *types.Character) (string, string, error) {
+	zones := gm.sortedZones()
+
+	for _, zone := range zones {
+		if len(zone.SubZones) == 0 {
+			continue
+		}
+		for _, common := range zone.CommonCharacters {
+			if common == character.ID {
+				return zone.ID, zone.SubZones[0].ID, nil
+			}
+		}
+	}
+
+	if zone, exists := gm.state.Zones[gm.config.Game.DefaultZone]; exists && len(zone.SubZones) > 0 {
+		for _, subZone := range zone.SubZones {
+			if subZone.ID == gm.config.Game.DefaultSubZone {
+				return zone.ID, subZone.ID, nil
+			}
+		}
+		return zone.ID, zone.SubZones[0].ID, nil
+	}
+
+	for _, zone := range zones {
+		if len(zone.SubZones) > 0 {
+			return zone.ID, zone.SubZones[0].ID, nil
+		}
+	}
+
+	return "", "", errors.New("nenhuma zona carregada")
+}
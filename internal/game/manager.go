@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/rand"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -23,55 +24,126 @@ type MessageSender interface {
 
 // GameManager handles the game state and operations
 type GameManager struct {
-	state         *types.GameState
-	stateLock     sync.RWMutex
-	storage       *GameStateStorage
-	config        config.Config
-	Logger        *zap.Logger
-	diceRoller    *DiceRoller
-	eventSys      *EventSystem
-	clientManager *whatsapp.ClientManager
-	messageSender interfaces.MessageSender
-	mu            sync.RWMutex
-	players       map[string]*types.Player
-	events        map[string][]*types.Event
+	state           *types.GameState
+	stateLock       sync.RWMutex
+	store           StateStore
+	persistence     *PersistenceSystem
+	config          config.Config
+	Logger          *zap.Logger
+	diceRoller      *DiceRoller
+	eventSys        *EventSystem
+	autoPilot       *AutoPilotSystem
+	travelSys       *TravelSystem
+	arcSys          *ArcSystem
+	worldSys        *WorldSystem
+	missionSys      *MissionSystem
+	notificationSys *NotificationSystem
+	calendarSys     *CalendarSystem
+	worldEventSys   *WorldEventSystem
+	clientManager   *whatsapp.ClientManager
+	messageSender   interfaces.MessageSender
+	mu              sync.RWMutex
+	players         map[string]*types.Player
+	events          map[string][]*types.Event
+
+	// Write-behind state: players, decisions, factions and subzones waiting
+	// for the next flush
+	dirty            map[string]struct{}
+	pendingDecisions []PlayerDecision
+	dirtyFactions    map[string]struct{}
+	dirtyWorld       map[string]struct{}
+	dirtyLock        sync.Mutex
+	flushSignal      chan struct{}
+
+	// Interactions waiting for consent, by the phone of the player who has to
+	// answer; guarded by stateLock
+	interactions map[string]*types.Interaction
+
+	// Group mission templates by ID, and the missions running by group JID;
+	// guarded by stateLock
+	missions      map[string]*types.Mission
+	groupMissions map[string]*types.GroupMission
+
+	// Seasons of the calendar by ID, and the ones going on; activeSeasons
+	// stays nil until the calendar is first checked. Guarded by stateLock.
+	seasons       map[string]*types.Season
+	activeSeasons map[string]activeSeason
+
+	// World event templates by ID, and the ones going on by template ID;
+	// guarded by stateLock
+	worldEvents       map[string]*types.WorldEvent
+	activeWorldEvents map[string]*worldEventRun
 }
 
 // Ensure GameManager satifies the interfaces.GameManager interface
 var _ interfaces.GameManager = (*GameManager)(nil)
 
-// NewGameManager creates a new game manager
-func NewGameManager(cfg config.Config) *GameManager {
-	// Create storage
-	storage := NewGameStateStorage("./data/game_state.json")
-
-	// Try to load existing state
-	state, err := storage.LoadGameState()
+// NewGameManager creates a new game manager backed by the given store. It
+// fails rather than starting over with an empty state when the saved state
+// can't be loaded, so a corrupt save never wipes the players.
+func NewGameManager(cfg config.Config, store StateStore) (*GameManager, error) {
+	state, err := loadState(store)
 	if err != nil {
-		// If there's an error loading, create a new state
-		state = &types.GameState{
-			Players:    make(map[string]*types.Player),
-			Characters: make(map[string]*types.Character),
-			Events:     make(map[string]*types.Event),
-			Actions:    make(map[string]*types.Action),
-			Zones:      make(map[string]*types.Zone),
-		}
+		return nil, fmt.Errorf("failed to load game state: %w", err)
 	}
 
 	gm := &GameManager{
-		state:      state,
-		storage:    storage,
-		config:     cfg,
-		Logger:     zap.NewNop(), // Will be set by the server
-		diceRoller: NewDiceRoller(),
-		players:    make(map[string]*types.Player),
-		events:     make(map[string][]*types.Event),
+		state:       state,
+		store:       store,
+		config:      cfg,
+		Logger:      zap.NewNop(), // Will be set by the server
+		diceRoller:  NewDiceRoller(),
+		players:     make(map[string]*types.Player),
+		events:      make(map[string][]*types.Event),
+		dirty:       make(map[string]struct{}),
+		flushSignal: make(chan struct{}, 1),
+
+		dirtyFactions: make(map[string]struct{}),
+		dirtyWorld:    make(map[string]struct{}),
+
+		interactions: make(map[string]*types.Interaction),
+
+		missions:      make(map[string]*types.Mission),
+		groupMissions: make(map[string]*types.GroupMission),
+
+		seasons: make(map[string]*types.Season),
+
+		worldEvents:       make(map[string]*types.WorldEvent),
+		activeWorldEvents: make(map[string]*worldEventRun),
 	}
 
 	// Sync players from state to runtime map
 	gm.syncPlayers()
 
-	return gm
+	return gm, nil
+}
+
+// loadState builds the game state from the players and catalog of a store
+func loadState(store StateStore) (*types.GameState, error) {
+	state, err := store.LoadCatalog()
+	if err != nil {
+		return nil, err
+	}
+
+	players, err := store.LoadPlayers()
+	if err != nil {
+		return nil, err
+	}
+	state.Players = players
+
+	factions, err := store.LoadFactions()
+	if err != nil {
+		return nil, err
+	}
+	state.Factions = factions
+
+	world, err := store.LoadWorld()
+	if err != nil {
+		return nil, err
+	}
+	state.World = world
+
+	return state, nil
 }
 
 // syncPlayers copies players from state to runtime map
@@ -96,10 +168,11 @@ func (gm *GameManager) syncPlayers() {
 // SetLogger sets the logger for the game manager and reinitializes the event system
 func (gm *GameManager) SetLogger(logger *zap.Logger) {
 	gm.Logger = logger
-	// Initialize event system with the proper logger
+	// Initialize event system with the proper logger, looking for due
+	// player checks every minute
 	gm.eventSys = NewEventSystem(
 		gm,
-		time.Duration(gm.config.Game.EventInterval)*time.Minute,
+		time.Minute,
 		gm.Logger,
 		gm.diceRoller,
 		&gm.config,
@@ -107,11 +180,216 @@ func (gm *GameManager) SetLogger(logger *zap.Logger) {
 	gm.Logger.Info("Event system initialized with logger",
 		zap.Int("event_interval_minutes", gm.config.Game.EventInterval),
 		zap.Int("event_probability", gm.config.Game.RandomEventProbability))
+
+	// Initialize auto-pilot system with the same logger
+	gm.autoPilot = NewAutoPilotSystem(
+		gm,
+		time.Duration(gm.config.Game.AutoPilotInterval)*time.Minute,
+		time.Duration(gm.config.WhatsApp.AutoReplyTimeout)*time.Second,
+		gm.Logger,
+	)
+
+	// Initialize the write-behind persistence system
+	flushInterval := time.Duration(gm.config.Database.FlushInterval) * time.Second
+	if flushInterval <= 0 {
+		flushInterval = 5 * time.Second
+	}
+	gm.persistence = NewPersistenceSystem(gm, flushInterval, gm.Logger)
+
+	// Initialize the travel system, checking arrivals every minute
+	gm.travelSys = NewTravelSystem(gm, time.Minute, gm.Logger)
+
+	// Initialize the arc system, sending delayed follow-ups every minute
+	gm.arcSys = NewArcSystem(gm, time.Minute, gm.Logger)
+
+	// Initialize the world system that cools the subzones down
+	decayInterval := time.Duration(gm.config.Game.WorldDecayInterval) * time.Minute
+	if decayInterval <= 0 {
+		decayInterval = 10 * time.Minute
+	}
+	gm.worldSys = NewWorldSystem(gm, decayInterval, gm.Logger)
+
+	// Initialize the mission system, checking deadlines every 30 seconds
+	gm.missionSys = NewMissionSystem(gm, 30*time.Second, gm.Logger)
+
+	// Initialize the notification system, delivering held messages every minute
+	gm.notificationSys = NewNotificationSystem(gm, time.Minute, gm.Logger)
+
+	// Initialize the calendar system, checking the seasons every minute
+	gm.calendarSys = NewCalendarSystem(gm, time.Minute, gm.Logger)
+
+	// Initialize the world event system, ending events every minute and
+	// rolling for a new one every world_event_interval minutes
+	worldEventInterval := time.Duration(gm.config.Game.WorldEventInterval) * time.Minute
+	if worldEventInterval <= 0 {
+		worldEventInterval = time.Hour
+	}
+	gm.worldEventSys = NewWorldEventSystem(gm, time.Minute, worldEventInterval, gm.Logger)
 }
 
-// saveState persists the current game state
-func (gm *GameManager) saveState() error {
-	return gm.storage.SaveGameState(gm.state)
+// markDirty queues a player for the next save. The persistence system writes
+// dirty players in batches, so callers never wait on disk I/O.
+func (gm *GameManager) markDirty(player *types.Player) {
+	gm.dirtyLock.Lock()
+	gm.dirty[player.PhoneNumber] = struct{}{}
+	pending := len(gm.dirty) + len(gm.pendingDecisions)
+	gm.dirtyLock.Unlock()
+
+	gm.requestFlushAt(pending)
+}
+
+// markFactionDirty queues a faction for the next save; a faction that is
+// gone from the state by then is deleted from the store
+func (gm *GameManager) markFactionDirty(factionID string) {
+	gm.dirtyLock.Lock()
+	gm.dirtyFactions[factionID] = struct{}{}
+	pending := len(gm.dirty) + len(gm.pendingDecisions) + len(gm.dirtyFactions)
+	gm.dirtyLock.Unlock()
+
+	gm.requestFlushAt(pending)
+}
+
+// markWorldDirty queues a subzone's dynamic state for the next save
+func (gm *GameManager) markWorldDirty(key string) {
+	gm.dirtyLock.Lock()
+	gm.dirtyWorld[key] = struct{}{}
+	pending := len(gm.dirty) + len(gm.pendingDecisions) + len(gm.dirtyFactions) + len(gm.dirtyWorld)
+	gm.dirtyLock.Unlock()
+
+	gm.requestFlushAt(pending)
+}
+
+// recordDecision adds a decision to the player's history and queues it for the next save
+func (gm *GameManager) recordDecision(player *types.Player, decision types.Decision) {
+	player.DecisionHistory = append(player.DecisionHistory, decision)
+
+	gm.dirtyLock.Lock()
+	gm.pendingDecisions = append(gm.pendingDecisions, PlayerDecision{
+		PhoneNumber: player.PhoneNumber,
+		Decision:    decision,
+	})
+	pending := len(gm.dirty) + len(gm.pendingDecisions)
+	gm.dirtyLock.Unlock()
+
+	gm.requestFlushAt(pending)
+}
+
+// requestFlushAt wakes the persistence system once enough changes are pending
+func (gm *GameManager) requestFlushAt(pending int) {
+	threshold := gm.config.Database.FlushThreshold
+	if threshold <= 0 || pending < threshold {
+		return
+	}
+
+	select {
+	case gm.flushSignal <- struct{}{}:
+	default:
+		// A flush is already requested
+	}
+}
+
+// Flush writes every dirty player, queued decision, dirty faction and dirty
+// subzone to the store. Changes that fail to save stay queued for the next flush.
+func (gm *GameManager) Flush() error {
+	gm.dirtyLock.Lock()
+	dirty := gm.dirty
+	decisions := gm.pendingDecisions
+	factions := gm.dirtyFactions
+	world := gm.dirtyWorld
+	gm.dirty = make(map[string]struct{})
+	gm.pendingDecisions = nil
+	gm.dirtyFactions = make(map[string]struct{})
+	gm.dirtyWorld = make(map[string]struct{})
+	gm.dirtyLock.Unlock()
+
+	if len(dirty) == 0 && len(decisions) == 0 && len(factions) == 0 && len(world) == 0 {
+		return nil
+	}
+
+	err := gm.writeDirty(dirty, decisions, factions, world)
+	if err != nil {
+		// Requeue everything so nothing is lost; saves are idempotent
+		gm.dirtyLock.Lock()
+		for phoneNumber := range dirty {
+			gm.dirty[phoneNumber] = struct{}{}
+		}
+		gm.pendingDecisions = append(decisions, gm.pendingDecisions...)
+		for factionID := range factions {
+			gm.dirtyFactions[factionID] = struct{}{}
+		}
+		for key := range world {
+			gm.dirtyWorld[key] = struct{}{}
+		}
+		gm.dirtyLock.Unlock()
+	}
+
+	return err
+}
+
+// writeDirty saves the given players, decisions, factions and subzones in
+// one batch. They are copied under stateLock, which is released before the
+// write so the game never waits on disk I/O.
+func (gm *GameManager) writeDirty(dirty map[string]struct{}, decisions []PlayerDecision, dirtyFactions, dirtyWorld map[string]struct{}) error {
+	batch, err := gm.dirtyBatch(dirty, decisions, dirtyFactions, dirtyWorld)
+	if err != nil {
+		return err
+	}
+
+	if len(batch.Players) == 0 && len(batch.Decisions) == 0 && len(batch.Factions) == 0 &&
+		len(batch.DeletedFactions) == 0 && len(batch.World) == 0 {
+		return nil
+	}
+
+	if err := gm.store.SaveBatch(batch); err != nil {
+		return fmt.Errorf("failed to save batch: %w", err)
+	}
+
+	return nil
+}
+
+// dirtyBatch builds a batch with copies of the given players, factions and
+// subzones as they are now; a dirty faction gone from the state is deleted
+func (gm *GameManager) dirtyBatch(dirty map[string]struct{}, decisions []PlayerDecision, dirtyFactions, dirtyWorld map[string]struct{}) (Batch, error) {
+	gm.stateLock.RLock()
+	defer gm.stateLock.RUnlock()
+
+	batch := Batch{Decisions: decisions}
+
+	for phoneNumber := range dirty {
+		player, exists := gm.state.Players[phoneNumber]
+		if !exists {
+			continue
+		}
+		var saved types.Player
+		if err := copyJSON(player, &saved); err != nil {
+			return Batch{}, fmt.Errorf("failed to copy player %s: %w", phoneNumber, err)
+		}
+		batch.Players = append(batch.Players, &saved)
+	}
+
+	for factionID := range dirtyFactions {
+		faction, exists := gm.state.Factions[factionID]
+		if !exists {
+			batch.DeletedFactions = append(batch.DeletedFactions, factionID)
+			continue
+		}
+		var saved types.Faction
+		if err := copyJSON(faction, &saved); err != nil {
+			return Batch{}, fmt.Errorf("failed to copy faction %s: %w", factionID, err)
+		}
+		batch.Factions = append(batch.Factions, &saved)
+	}
+
+	for key := range dirtyWorld {
+		state, exists := gm.state.World[key]
+		if !exists {
+			continue
+		}
+		saved := *state
+		batch.World = append(batch.World, &saved)
+	}
+
+	return batch, nil
 }
 
 // RegisterPlayer adds a new player to the game
@@ -148,10 +426,8 @@ func (gm *GameManager) RegisterPlayer(phoneNumber, name string) (*types.Player,
 		//TODO: do something
 	}
 
-	// Save the state
-	if err := gm.saveState(); err != nil {
-		return nil, fmt.Errorf("falha ao salvar o estado do jogo: %w", err)
-	}
+	// Queue the player for the next save
+	gm.markDirty(player)
 
 	return player, nil
 }
@@ -186,36 +462,21 @@ func (gm *GameManager) SelectCharacter(phoneNumber, characterID string) error {
 		return errors.New("personagem não encontrado")
 	}
 
-	// Assign character to player
+	// Find where the character starts before changing the player
+	zoneID, subZoneID, err := gm.startingLocation(character)
+	if err != nil {
+		return err
+	}
+
+	// Assign character to player, with stats of their own to grow
 	player.CurrentCharacter = character
+	player.Stats = gm.initialStats(character, player.XP)
 	player.LastActiveAt = time.Now()
+	player.CurrentZone = zoneID
+	player.CurrentSubZone = subZoneID
 
-	// Set initial zone and subzone based on character type
-	switch character.Type {
-	case "nerd_hacker":
-		player.CurrentZone = "copacabana"
-		player.CurrentSubZone = "praia"
-	case "traficante":
-		player.CurrentZone = "centro"
-		player.CurrentSubZone = "lapa"
-	case "policial":
-		player.CurrentZone = "centro"
-		player.CurrentSubZone = "delegacia"
-	case "artista":
-		player.CurrentZone = "ipanema"
-		player.CurrentSubZone = "praia"
-	case "empresario":
-		player.CurrentZone = "barra"
-		player.CurrentSubZone = "shopping"
-	default:
-		player.CurrentZone = "centro"
-		player.CurrentSubZone = "lapa"
-	}
-
-	// Save state
-	if err := gm.saveState(); err != nil {
-		return fmt.Errorf("falha ao salvar o estado do jogo: %w", err)
-	}
+	// Queue the player for the next save
+	gm.markDirty(player)
 
 	return nil
 }
@@ -236,6 +497,16 @@ func (gm *GameManager) PerformAction(phoneNumber, actionID string) (*types.Outco
 		return nil, errors.New("jogador não selecionou um personagem")
 	}
 
+	// Players on the road can't act until they arrive
+	if player.Travel != nil {
+		return nil, errors.New("você está em trânsito")
+	}
+
+	// Burned out players can only rest
+	if player.Burnout && !gm.isBurnoutRecoveryAction(actionID) {
+		return nil, errors.New("você está em burnout")
+	}
+
 	// Get action
 	action, exists := gm.state.Actions[actionID]
 	if !exists {
@@ -274,23 +545,15 @@ func (gm *GameManager) PerformAction(phoneNumber, actionID string) (*types.Outco
 		return nil, errors.New("ação não disponível na localização atual")
 	}
 
-	// Get attribute value for bonus
-	var attributeValue int
-	switch action.BonusAttribute {
-	case "carisma":
-		attributeValue = player.CurrentCharacter.Carisma
-	case "proficiencia":
-		attributeValue = player.CurrentCharacter.Proficiencia
-	case "rede":
-		attributeValue = player.CurrentCharacter.Rede
-	case "moralidade":
-		attributeValue = player.CurrentCharacter.Moralidade
-	case "resiliencia":
-		attributeValue = player.CurrentCharacter.Resiliencia
-	default:
-		attributeValue = 0
+	// A world event going on may shut some actions down
+	worldEvent := gm.worldEventAt(player.CurrentZone, player.CurrentSubZone)
+	if worldEvent != nil && containsString(worldEvent.event.Modifiers.BlockedActions, actionID) {
+		return nil, fmt.Errorf("não dá pra fazer isso agora: %s", worldEvent.event.Title)
 	}
 
+	// Get the player's own attribute value for bonus
+	attributeValue := playerAttribute(player, action.BonusAttribute)
+
 	// Calculate bonus multiplier (1% per point)
 	bonusMultiplier := float64(attributeValue) / 100.0
 
@@ -300,8 +563,11 @@ func (gm *GameManager) PerformAction(phoneNumber, actionID string) (*types.Outco
 	outcome.MoneyChange = int(float64(outcome.MoneyChange) * (1 + bonusMultiplier))
 	outcome.InfluenceChange = int(float64(outcome.InfluenceChange) * (1 + bonusMultiplier))
 
-	// Apply zone multiplier
-	zoneMultiplier := float64(currentSubZone.RewardMultiplier) / 100.0
+	// Apply zone multiplier, plus the bonus of the player's faction territory
+	// and of the seasons going on there
+	rewardMultiplier := currentSubZone.RewardMultiplier + gm.territoryBonus(phoneNumber, player.CurrentZone, player.CurrentSubZone) +
+		gm.seasonBonus(player.CurrentZone, player.CurrentSubZone)
+	zoneMultiplier := float64(rewardMultiplier) / 100.0
 	outcome.XPChange = int(float64(outcome.XPChange) * (1 + zoneMultiplier))
 	outcome.MoneyChange = int(float64(outcome.MoneyChange) * (1 + zoneMultiplier))
 	outcome.InfluenceChange = int(float64(outcome.InfluenceChange) * (1 + zoneMultiplier))
@@ -317,41 +583,60 @@ func (gm *GameManager) PerformAction(phoneNumber, actionID string) (*types.Outco
 		}
 	}
 
+	// Stressed players get less out of what they do
+	applyStressYield(&outcome, playerStressBand(player))
+
+	// Hustling under the eyes of the police pays less
+	applyPoliceYield(&outcome, gm.peekSubZoneState(player.CurrentZone, player.CurrentSubZone))
+
+	// Some things pay off more at some times of the day or the week
+	now := time.Now()
+	if notes := applyTimeModifiers(&outcome, action, gm.gameTime(now), player.CurrentSubZone); len(notes) > 0 {
+		outcome.Description += " " + strings.Join(notes, " ")
+	}
+
+	// So does a world event going on there
+	if worldEvent != nil {
+		applyWorldEventModifiers(&outcome, worldEvent.event.Modifiers)
+	}
+
 	// Apply outcome to player
 	player.XP += outcome.XPChange
 	player.Money += outcome.MoneyChange
 	player.Influence += outcome.InfluenceChange
 	player.Stress += outcome.StressChange
 
-	// Ensure stress stays within bounds
-	if player.Stress < 0 {
-		player.Stress = 0
-	}
-	if player.Stress > 100 {
-		player.Stress = 100
-	}
+	// Keep stress within bounds and burn out (or recover) the player
+	gm.updateBurnout(player)
+
+	// New XP may mean a new level
+	gm.checkLevelUp(player)
+
+	// Progress may also complete an evolution path
+	gm.checkEvolution(player)
+
+	// What the player did heats up or calms down the place
+	gm.recordSubZoneImpact(player, outcome.HeatChange)
 
 	// Update player's last active time
-	player.LastActiveAt = time.Now()
+	player.LastActiveAt = now
 
 	// Record decision
 	decision := types.Decision{
 		ID:              uuid.New().String(),
 		EventID:         "action_" + actionID,
 		Choice:          actionID,
-		Timestamp:       time.Now(),
+		Timestamp:       now,
 		Outcome:         action.Name,
 		XPChange:        outcome.XPChange,
 		MoneyChange:     outcome.MoneyChange,
 		InfluenceChange: outcome.InfluenceChange,
 		StressChange:    outcome.StressChange,
 	}
-	player.DecisionHistory = append(player.DecisionHistory, decision)
+	gm.recordDecision(player, decision)
 
-	// Save state
-	if err := gm.saveState(); err != nil {
-		return nil, fmt.Errorf("falha ao salvar o estado do jogo: %w", err)
-	}
+	// Queue the player for the next save
+	gm.markDirty(player)
 
 	return &outcome, nil
 }
@@ -373,28 +658,17 @@ func (gm *GameManager) GenerateEvent(phoneNumber string) (*types.Event, error) {
 	}
 
 	// Get all events that match player's current state
+	now := time.Now()
 	var eligibleEvents []*types.Event
 	for _, event := range gm.state.Events {
-		// Check requirements
-		if player.XP < event.MinXP || player.Money < event.MinMoney || player.Influence < event.MinInfluence {
+		// Transit, encounter and chain events are fired by their own systems
+		if isSystemEvent(event) {
 			continue
 		}
 
-		// Check zone requirements if specified
-		if len(event.RequiredZone) > 0 {
-			zoneMatch := false
-			for _, zone := range event.RequiredZone {
-				if zone == player.CurrentZone {
-					zoneMatch = true
-					break
-				}
-			}
-			if !zoneMatch {
-				continue
-			}
+		if gm.eventEligible(player, event, now) {
+			eligibleEvents = append(eligibleEvents, event)
 		}
-
-		eligibleEvents = append(eligibleEvents, event)
 	}
 
 	// If no eligible events, return error
@@ -405,17 +679,26 @@ func (gm *GameManager) GenerateEvent(phoneNumber string) (*types.Event, error) {
 	// Select random event
 	selectedEvent := eligibleEvents[rand.Intn(len(eligibleEvents))]
 
+	// Keep a copy of the event as the player's pending one so it can be answered
+	eventCopy := *selectedEvent
+	player.CurrentEvent = &eventCopy
+
 	// Update player's last event time
-	player.LastEventAt = time.Now()
+	player.LastEventAt = now
 
-	// Save state
-	if err := gm.saveState(); err != nil {
-		return nil, fmt.Errorf("falha ao salvar o estado do jogo: %w", err)
-	}
+	// Queue the player for the next save
+	gm.markDirty(player)
 
-	return selectedEvent, nil
+	return &eventCopy, nil
 }
 
+// Errors of ProcessEventChoice when the event answered isn't the player's
+// current one, e.g. because it was answered or replaced meanwhile
+var (
+	errNoPendingEvent  = errors.New("nenhum evento pendente")
+	errEventNotPending = errors.New("evento não encontrado")
+)
+
 // ProcessEventChoice handles a player's choice in an event
 func (gm *GameManager) ProcessEventChoice(phoneNumber, eventID, optionID string) (*types.Outcome, error) {
 	gm.stateLock.Lock()
@@ -432,10 +715,13 @@ func (gm *GameManager) ProcessEventChoice(phoneNumber, eventID, optionID string)
 		return nil, errors.New("jogador não selecionou um personagem")
 	}
 
-	// Get event
-	event, exists := gm.state.Events[eventID]
-	if !exists {
-		return nil, errors.New("evento não encontrado")
+	// Only the event the player is currently facing can be answered
+	event := player.CurrentEvent
+	if event == nil {
+		return nil, errNoPendingEvent
+	}
+	if event.ID != eventID {
+		return nil, errEventNotPending
 	}
 
 	// Find option
@@ -451,29 +737,23 @@ func (gm *GameManager) ProcessEventChoice(phoneNumber, eventID, optionID string)
 		return nil, errors.New("opção não encontrada")
 	}
 
-	// Determine attribute value for check
-	var attributeValue int
-	switch selectedOption.RequiredAttribute {
-	case "carisma":
-		attributeValue = player.CurrentCharacter.Carisma
-	case "proficiencia":
-		attributeValue = player.CurrentCharacter.Proficiencia
-	case "rede":
-		attributeValue = player.CurrentCharacter.Rede
-	case "moralidade":
-		attributeValue = player.CurrentCharacter.Moralidade
-	case "resiliencia":
-		attributeValue = player.CurrentCharacter.Resiliencia
-	default:
-		attributeValue = 0
-	}
+	// Determine the player's own attribute value for check
+	attributeValue := playerAttribute(player, selectedOption.RequiredAttribute)
 
-	// Roll dice (1d20 + attribute)
-	roll := rand.Intn(20) + 1 + attributeValue
+	// Roll dice (1d20 + attribute), with the penalty of the player's stress band
+	roll := rand.Intn(20) + 1 + attributeValue + playerStressBand(player).RollPenalty
+
+	// Encounters are contested: the player has to beat the predator's roll,
+	// and ties go to the predator
+	required := selectedOption.DifficultyLevel
+	if event.Encounter != nil {
+		required = rollPredator(event.Encounter, selectedOption.RequiredAttribute) + 1
+	}
+	success := roll >= required
 
 	// Determine outcome
 	var outcome types.Outcome
-	if roll >= selectedOption.DifficultyLevel {
+	if success {
 		outcome = selectedOption.SuccessOutcome
 	} else {
 		outcome = selectedOption.FailureOutcome
@@ -485,13 +765,26 @@ func (gm *GameManager) ProcessEventChoice(phoneNumber, eventID, optionID string)
 	player.Influence += outcome.InfluenceChange
 	player.Stress += outcome.StressChange
 
-	// Ensure stress stays within bounds
-	if player.Stress < 0 {
-		player.Stress = 0
-	}
-	if player.Stress > 100 {
-		player.Stress = 100
-	}
+	// Keep stress within bounds and burn out (or recover) the player
+	gm.updateBurnout(player)
+
+	// New XP may mean a new level
+	gm.checkLevelUp(player)
+
+	// Progress may also complete an evolution path
+	gm.checkEvolution(player)
+
+	// The choice heats up or calms down the place where it was made
+	gm.recordSubZoneImpact(player, outcome.HeatChange)
+
+	// The event has been answered
+	player.CurrentEvent = nil
+
+	// A world event keeps what everyone did for its summary
+	gm.recordWorldEventChoice(player, event, optionID, success, outcome)
+
+	// A chained event continues the player's arc, right away or later
+	gm.advanceArc(player, event, optionID, success, outcome, time.Now())
 
 	// Update location if specified
 	if outcome.NewZone != "" {
@@ -510,19 +803,22 @@ func (gm *GameManager) ProcessEventChoice(phoneNumber, eventID, optionID string)
 		EventID:         eventID,
 		Choice:          optionID,
 		Timestamp:       time.Now(),
-		Outcome:         fmt.Sprintf("Roll: %d, Required: %d, Success: %t", roll, selectedOption.DifficultyLevel, roll >= selectedOption.DifficultyLevel),
+		Outcome:         fmt.Sprintf("Roll: %d, Required: %d, Success: %t", roll, required, success),
 		XPChange:        outcome.XPChange,
 		MoneyChange:     outcome.MoneyChange,
 		InfluenceChange: outcome.InfluenceChange,
 		StressChange:    outcome.StressChange,
 	}
-	player.DecisionHistory = append(player.DecisionHistory, decision)
+	gm.recordDecision(player, decision)
 
-	// Save state
-	if err := gm.saveState(); err != nil {
-		return nil, fmt.Errorf("falha ao salvar o estado do jogo: %w", err)
+	// A predator played by someone gets to know how it went
+	if event.Encounter != nil && event.Encounter.PredatorPhone != "" {
+		go gm.notifyPlayerAbout(event.Encounter.PredatorPhone, categoryPvP, encounterReport(player, success))
 	}
 
+	// Queue the player for the next save
+	gm.markDirty(player)
+
 	return &outcome, nil
 }
 
@@ -546,14 +842,76 @@ func (gm *GameManager) SetPlayerStatus(phoneNumber, status string) error {
 	player.Status = status
 	player.LastActiveAt = time.Now()
 
-	// Save state
-	if err := gm.saveState(); err != nil {
-		return fmt.Errorf("falha ao salvar o estado do jogo: %w", err)
-	}
+	// Queue the player for the next save
+	gm.markDirty(player)
 
 	return nil
 }
 
+// autoPilotPlayers returns copies of the players on auto-pilot who have a
+// character, so the auto-pilot can decide from them without racing the systems
+// that change players under stateLock
+func (gm *GameManager) autoPilotPlayers() []*types.Player {
+	gm.stateLock.RLock()
+	defer gm.stateLock.RUnlock()
+
+	var players []*types.Player
+	for _, player := range gm.state.Players {
+		if player.Status != "autopilot" || player.CurrentCharacter == nil {
+			continue
+		}
+
+		var playerCopy types.Player
+		if err := copyJSON(player, &playerCopy); err != nil {
+			gm.Logger.Error("Failed to copy auto-pilot player",
+				zap.String("phone_number", player.PhoneNumber),
+				zap.Error(err))
+			continue
+		}
+		players = append(players, &playerCopy)
+	}
+
+	return players
+}
+
+// takeOverIdlePlayers switches to auto-pilot the active players who left
+// their event unanswered for longer than timeout. The check and the switch
+// happen under one lock, so a player who answers in time is never taken over.
+// It returns copies of the players switched, with the event to resolve.
+func (gm *GameManager) takeOverIdlePlayers(timeout time.Duration, now time.Time) []*types.Player {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	var players []*types.Player
+	for _, player := range gm.state.Players {
+		if player.Status != "active" || player.CurrentCharacter == nil || player.CurrentEvent == nil {
+			continue
+		}
+		if now.Sub(player.LastEventAt) < timeout {
+			continue
+		}
+
+		var playerCopy types.Player
+		if err := copyJSON(player, &playerCopy); err != nil {
+			gm.Logger.Error("Failed to copy idle player",
+				zap.String("phone_number", player.PhoneNumber),
+				zap.Error(err))
+			continue
+		}
+
+		player.Status = "autopilot"
+		player.LastActiveAt = now
+
+		// Queue the player for the next save
+		gm.markDirty(player)
+
+		playerCopy.Status = player.Status
+		players = append(players, &playerCopy)
+	}
+
+	return players
+}
+
 // GetPlayerStatus retrieves a player's current status and stats
 func (gm *GameManager) GetPlayerStatus(phoneNumber string) (map[string]interface{}, error) {
 	gm.stateLock.RLock()
@@ -585,6 +943,24 @@ func (gm *GameManager) GetPlayerStatus(phoneNumber string) (map[string]interface
 		}
 	}
 
+	location := fmt.Sprintf("%s, %s", zone.Name, subZoneName)
+	if player.Travel != nil {
+		location = fmt.Sprintf("em trânsito para %s, chegada às %s",
+			gm.subZoneDisplayName(player.Travel.ToZone, player.Travel.ToSubZone),
+			player.Travel.ArrivesAt.Format("15:04"))
+	}
+
+	// Characters missing from the catalog leave the player without stats
+	stats := player.Stats
+	if stats == nil {
+		stats = gm.initialStats(player.CurrentCharacter, player.XP)
+	}
+
+	playerStatus := player.Status
+	if player.Burnout {
+		playerStatus = "burnout"
+	}
+
 	// Build status response
 	status := map[string]interface{}{
 		"name":           player.Name,
@@ -594,15 +970,37 @@ func (gm *GameManager) GetPlayerStatus(phoneNumber string) (map[string]interface
 		"money":          player.Money,
 		"influence":      player.Influence,
 		"stress":         player.Stress,
-		"location":       fmt.Sprintf("%s, %s", zone.Name, subZoneName),
-		"status":         player.Status,
-		"attributes": map[string]int{
-			"carisma":      player.CurrentCharacter.Carisma,
-			"proficiencia": player.CurrentCharacter.Proficiencia,
-			"rede":         player.CurrentCharacter.Rede,
-			"moralidade":   player.CurrentCharacter.Moralidade,
-			"resiliencia":  player.CurrentCharacter.Resiliencia,
-		},
+		"stress_band":    playerStressBand(player).Name,
+		"level":          stats.Level,
+		"next_level_xp":  gm.nextLevelXP(stats.Level),
+		"points":         stats.AttributePoints,
+		"location":       location,
+		"status":         playerStatus,
+		"attributes":     statsAttributes(stats),
+		"game_time":      formatGameTime(gm.gameTime(time.Now())),
+	}
+
+	if faction := gm.playerFaction(phoneNumber); faction != nil {
+		status["faction"] = faction.Name
+	}
+
+	if seasons := gm.activeSeasonNames(); len(seasons) > 0 {
+		status["seasons"] = strings.Join(seasons, ", ")
+	}
+
+	if run := gm.worldEventAt(player.CurrentZone, player.CurrentSubZone); run != nil {
+		status["world_event"] = fmt.Sprintf("%s (até %s)", run.event.Title, run.endsAt.Format("15:04"))
+	}
+
+	if title := gm.arcTitle(player); title != "" {
+		status["arc"] = title
+		status["arc_step"] = len(player.Arc.Path) + 1
+	}
+
+	if player.Travel == nil {
+		subZoneState := gm.peekSubZoneState(player.CurrentZone, player.CurrentSubZone)
+		status["heat"] = subZoneState.Heat
+		status["police"] = subZoneState.Police
 	}
 
 	return status, nil
@@ -616,6 +1014,22 @@ func (gm *GameManager) LoadCharacters(characters []*types.Character) {
 	for _, character := range characters {
 		gm.state.Characters[character.ID] = character
 	}
+
+	// Point saved players at the freshly loaded character definitions
+	for _, player := range gm.state.Players {
+		if player.CurrentCharacter == nil {
+			continue
+		}
+		if character, exists := gm.state.Characters[player.CurrentCharacter.ID]; exists {
+			player.CurrentCharacter = character
+
+			// Players from before stats existed start from the template
+			if player.Stats == nil {
+				player.Stats = gm.initialStats(character, player.XP)
+				gm.markDirty(player)
+			}
+		}
+	}
 }
 
 // LoadEvents loads event definitions into the game state
@@ -630,6 +1044,11 @@ func (gm *GameManager) LoadEvents(events []*types.Event) {
 		// Store in state
 		gm.state.Events[event.ID] = event
 
+		// Transit, encounter, chain and seasonal events are fired or drawn by their own systems, not from the zone pools
+		if isSystemEvent(event) {
+			continue
+		}
+
 		// Organize by zone
 		if len(event.RequiredZone) > 0 {
 			// Add to each required zone
@@ -687,6 +1106,7 @@ func (gm *GameManager) LoadZones(zones []*types.Zone) {
 				RiskLevel:        subZone.RiskLevel,
 				RewardMultiplier: subZone.RewardMultiplier,
 				AvailableActions: subZone.AvailableActions,
+				ArrivalMessages:  subZone.ArrivalMessages,
 			}
 		}
 
@@ -694,8 +1114,8 @@ func (gm *GameManager) LoadZones(zones []*types.Zone) {
 		gm.state.Zones[zone.ID] = newZone
 	}
 
-	// Save the updated state
-	if err := gm.saveState(); err != nil {
+	// Save the updated catalog
+	if err := gm.store.SaveCatalog(gm.state); err != nil {
 		gm.Logger.Error("failed to save game state after loading zones", zap.Error(err))
 	}
 }
@@ -723,6 +1143,25 @@ func (gm *GameManager) GetAvailableCharacters() []*types.Character {
 	return characters
 }
 
+// GetActions returns every loaded action, sorted by ID
+func (gm *GameManager) GetActions() []*types.Action {
+	gm.stateLock.RLock()
+	defer gm.stateLock.RUnlock()
+
+	ids := make([]string, 0, len(gm.state.Actions))
+	for id := range gm.state.Actions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	actions := make([]*types.Action, 0, len(ids))
+	for _, id := range ids {
+		actions = append(actions, gm.state.Actions[id])
+	}
+
+	return actions
+}
+
 // GetAvailableActions returns actions available to a player in their current location
 func (gm *GameManager) GetAvailableActions(phoneNumber string) ([]*types.Action, error) {
 	gm.stateLock.RLock()
@@ -758,50 +1197,30 @@ func (gm *GameManager) GetAvailableActions(phoneNumber string) ([]*types.Action,
 		return nil, errors.New("subzona inválida")
 	}
 
+	// A world event going on may shut some actions down
+	var blocked []string
+	if run := gm.worldEventAt(player.CurrentZone, player.CurrentSubZone); run != nil {
+		blocked = run.event.Modifiers.BlockedActions
+	}
+
 	// Get available actions
 	availableActions := make([]*types.Action, 0)
 	for _, actionID := range currentSubZone.AvailableActions {
-		if action, exists := gm.state.Actions[actionID]; exists {
-			availableActions = append(availableActions, action)
+		// Burned out players can only rest
+		if player.Burnout && !gm.isBurnoutRecoveryAction(actionID) {
+			continue
 		}
-	}
-
-	return availableActions, nil
-}
-
-// MovePlayer moves a player to a new zone and subzone
-func (gm *GameManager) MovePlayer(playerID, zoneID, subZoneID string) error {
-	player, err := gm.GetPlayer(playerID)
-	if err != nil {
-		return err
-	}
 
-	zone, exists := gm.state.Zones[zoneID]
-	if !exists {
-		return fmt.Errorf("zona não encontrada: %s", zoneID)
-	}
-
-	var subZone *types.SubZone
-	for _, sz := range zone.SubZones {
-		if sz.ID == subZoneID {
-			subZone = &sz
-			break
+		if containsString(blocked, actionID) {
+			continue
 		}
-	}
-
-	if subZone == nil {
-		return fmt.Errorf("subzona não encontrada: %s", subZoneID)
-	}
-
-	player.CurrentZone = zoneID
-	player.CurrentSubZone = subZoneID
 
-	// Save state
-	if err := gm.saveState(); err != nil {
-		return fmt.Errorf("falha ao salvar o estado do jogo: %w", err)
+		if action, exists := gm.state.Actions[actionID]; exists {
+			availableActions = append(availableActions, action)
+		}
 	}
 
-	return nil
+	return availableActions, nil
 }
 
 // GetZone retrieves a zone by ID
@@ -851,9 +1270,19 @@ func (gm *GameManager) TriggerRandomEvent(phoneNumber string) (*types.Event, err
 		zap.String("name", player.Name),
 		zap.String("current_zone", player.CurrentZone))
 
-	// Get available events for player's current zone
-	zoneEvents, exists := gm.events[player.CurrentZone]
-	if !exists || len(zoneEvents) == 0 {
+	// Get the events of the player's current zone that can happen to them now
+	now := time.Now()
+	var zoneEvents []*types.Event
+	for _, event := range gm.events[player.CurrentZone] {
+		if gm.eventEligible(player, event, now) {
+			zoneEvents = append(zoneEvents, event)
+		}
+	}
+
+	// Events of the seasons going on are drawn more often
+	seasonal := gm.seasonalEvents(player, now)
+
+	if len(zoneEvents) == 0 && len(seasonal) == 0 {
 		gm.Logger.Error("No events available for player's zone",
 			zap.String("phone_number", phoneNumber),
 			zap.String("zone", player.CurrentZone))
@@ -863,10 +1292,11 @@ func (gm *GameManager) TriggerRandomEvent(phoneNumber string) (*types.Event, err
 	gm.Logger.Info("Found events for zone",
 		zap.String("phone_number", phoneNumber),
 		zap.String("zone", player.CurrentZone),
-		zap.Int("available_events", len(zoneEvents)))
+		zap.Int("available_events", len(zoneEvents)),
+		zap.Int("seasonal_events", len(seasonal)))
 
 	// Select a random event
-	event := zoneEvents[rand.Intn(len(zoneEvents))]
+	event := drawEvent(zoneEvents, seasonal)
 
 	gm.Logger.Info("Selected random event",
 		zap.String("phone_number", phoneNumber),
@@ -880,15 +1310,12 @@ func (gm *GameManager) TriggerRandomEvent(phoneNumber string) (*types.Event, err
 	// Clear any existing event first
 	player.CurrentEvent = nil
 
-	// Set the new event on the player
+	// Set the new event on the player and start the reply clock
 	player.CurrentEvent = &eventCopy
+	player.LastEventAt = now
 
-	// Save the state
-	if err := gm.saveState(); err != nil {
-		// If save fails, clear the event to maintain consistency
-		player.CurrentEvent = nil
-		return nil, fmt.Errorf("falha ao salvar o estado do jogo: %w", err)
-	}
+	// Queue the player for the next save
+	gm.markDirty(player)
 
 	return &eventCopy, nil
 }
@@ -903,26 +1330,18 @@ func (gm *GameManager) SetMessageSender(sender interfaces.MessageSender) {
 	gm.messageSender = sender
 }
 
-// SendMessage sends a message to a player
+// SendMessage sends a message the game sends on its own to a player. It
+// waits for the end of the player's quiet hours if they are in them.
 func (gm *GameManager) SendMessage(playerID string, message string) error {
+	return gm.sendNotification(playerID, categoryGeneral, message)
+}
+
+// SendGroupMessage posts a message in a group chat
+func (gm *GameManager) SendGroupMessage(groupJID string, message string) error {
 	if gm.messageSender == nil {
 		return fmt.Errorf("message sender not set")
 	}
 
-	// Get player by ID first
-	var player *types.Player
-	for _, p := range gm.state.Players {
-		if p.ID == playerID || p.PhoneNumber == playerID {
-			player = p
-			break
-		}
-	}
-
-	if player == nil {
-		return fmt.Errorf("jogador não encontrado: %s", playerID)
-	}
-
-	// Get the bot's phone number from the client manager
 	if gm.clientManager == nil {
 		return fmt.Errorf("client manager not set")
 	}
@@ -932,9 +1351,7 @@ func (gm *GameManager) SendMessage(playerID string, message string) error {
 		return fmt.Errorf("failed to get bot phone number: %w", err)
 	}
 
-	// Send message through message sender using the bot's phone number
-	_, err = gm.messageSender.SendMessage(botPhoneNumber, player.PhoneNumber, message)
-	if err != nil {
+	if _, err := gm.messageSender.SendMessage(botPhoneNumber, groupJID, message); err != nil {
 		return fmt.Errorf("failed to send message: %w", err)
 	}
 
@@ -950,3 +1367,94 @@ func (gm *GameManager) StartEventSystem() {
 func (gm *GameManager) StopEventSystem() {
 	gm.eventSys.Stop()
 }
+
+// StartPersistenceSystem starts saving dirty players in the background
+func (gm *GameManager) StartPersistenceSystem() {
+	gm.persistence.Start()
+}
+
+// StopPersistenceSystem stops the background saves; call Flush afterwards to
+// write whatever is still pending
+func (gm *GameManager) StopPersistenceSystem() {
+	gm.persistence.Stop()
+}
+
+// StartTravelSystem starts completing trips as they arrive
+func (gm *GameManager) StartTravelSystem() {
+	gm.travelSys.Start()
+}
+
+// StopTravelSystem stops the travel system
+func (gm *GameManager) StopTravelSystem() {
+	gm.travelSys.Stop()
+}
+
+// StartArcSystem starts sending the delayed follow-ups of event chains
+func (gm *GameManager) StartArcSystem() {
+	gm.arcSys.Start()
+}
+
+// StopArcSystem stops the arc system
+func (gm *GameManager) StopArcSystem() {
+	gm.arcSys.Stop()
+}
+
+// StartWorldSystem starts cooling the subzones down over time
+func (gm *GameManager) StartWorldSystem() {
+	gm.worldSys.Start()
+}
+
+// StopWorldSystem stops the world system
+func (gm *GameManager) StopWorldSystem() {
+	gm.worldSys.Stop()
+}
+
+// StartMissionSystem starts moving group missions on as their deadlines pass
+func (gm *GameManager) StartMissionSystem() {
+	gm.missionSys.Start()
+}
+
+// StopMissionSystem stops the mission system
+func (gm *GameManager) StopMissionSystem() {
+	gm.missionSys.Stop()
+}
+
+// StartNotificationSystem starts delivering messages held during quiet hours
+func (gm *GameManager) StartNotificationSystem() {
+	gm.notificationSys.Start()
+}
+
+// StopNotificationSystem stops the notification system
+func (gm *GameManager) StopNotificationSystem() {
+	gm.notificationSys.Stop()
+}
+
+// StartCalendarSystem starts opening and closing the seasons of the calendar
+func (gm *GameManager) StartCalendarSystem() {
+	gm.calendarSys.Start()
+}
+
+// StopCalendarSystem stops the calendar system
+func (gm *GameManager) StopCalendarSystem() {
+	gm.calendarSys.Stop()
+}
+
+// StartWorldEventSystem starts world events in the zones of the city
+func (gm *GameManager) StartWorldEventSystem() {
+	gm.worldEventSys.Start()
+}
+
+// StopWorldEventSystem stops the world event system
+func (gm *GameManager) StopWorldEventSystem() {
+	gm.worldEventSys.Stop()
+}
+
+// StartAutoPilotSystem starts the auto-pilot system
+func (gm *GameManager) StartAutoPilotSystem() {
+	gm.autoPilot.Start()
+}
+
+// StopAutoPilotSystem stops the auto-pilot system
+func (gm *GameManager) StopAutoPilotSystem() {
+	gm.autoPilot.Stop()
+}
@@ -0,0 +1,106 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/user/vida-loka-strategy/config"
+	"github.com/user/vida-loka-strategy/internal/types"
+)
+
+// countingStore is a MemoryStore that counts its writes
+type countingStore struct {
+	*MemoryStore
+	writes int
+}
+
+func (cs *countingStore) SavePlayers(players ...*types.Player) error {
+	cs.writes++
+	return cs.MemoryStore.SavePlayers(players...)
+}
+
+func (cs *countingStore) SaveBatch(batch Batch) error {
+	cs.writes++
+	return cs.MemoryStore.SaveBatch(batch)
+}
+
+func (cs *countingStore) SaveFactions(factions ...*types.Faction) error {
+	cs.writes++
+	return cs.MemoryStore.SaveFactions(factions...)
+}
+
+func (cs *countingStore) DeleteFaction(id string) error {
+	cs.writes++
+	return cs.MemoryStore.DeleteFaction(id)
+}
+
+func (cs *countingStore) SaveWorld(states ...*types.SubZoneState) error {
+	cs.writes++
+	return cs.MemoryStore.SaveWorld(states...)
+}
+
+func TestFlushWritesOnce(t *testing.T) {
+	catalog := newEmptyGameState()
+	catalog.Characters["malandro"] = &types.Character{ID: "malandro", Name: "Malandro"}
+	catalog.Actions["trabalhar"] = &types.Action{
+		ID:          "trabalhar",
+		Name:        "Trabalhar",
+		BaseOutcome: types.Outcome{XPChange: 5, MoneyChange: 10, HeatChange: 1},
+	}
+	catalog.Zones["centro"] = &types.Zone{
+		ID: "centro",
+		SubZones: []types.SubZone{
+			{ID: "lapa", AvailableActions: []string{"trabalhar"}},
+		},
+	}
+
+	store := &countingStore{MemoryStore: NewMemoryStore()}
+	if err := store.MemoryStore.SaveCatalog(catalog); err != nil {
+		t.Fatalf("failed to save catalog: %v", err)
+	}
+	if err := store.MemoryStore.SavePlayers(&types.Player{
+		PhoneNumber:      "5521999990000",
+		Name:             "Ana",
+		Status:           "active",
+		CurrentCharacter: catalog.Characters["malandro"],
+		CurrentZone:      "centro",
+		CurrentSubZone:   "lapa",
+	}); err != nil {
+		t.Fatalf("failed to save player: %v", err)
+	}
+
+	gm, err := NewGameManager(config.Config{}, store)
+	if err != nil {
+		t.Fatalf("failed to create game manager: %v", err)
+	}
+
+	for i := 0; i < 50; i++ {
+		if _, err := gm.PerformAction("5521999990000", "trabalhar"); err != nil {
+			t.Fatalf("action %d failed: %v", i, err)
+		}
+	}
+
+	if store.writes != 0 {
+		t.Fatalf("writes before flush = %d, want 0", store.writes)
+	}
+	if err := gm.Flush(); err != nil {
+		t.Fatalf("failed to flush: %v", err)
+	}
+	if store.writes != 1 {
+		t.Errorf("writes = %d, want 1", store.writes)
+	}
+
+	players, err := store.LoadPlayers()
+	if err != nil {
+		t.Fatalf("failed to load players: %v", err)
+	}
+	if player := players["5521999990000"]; player == nil || len(player.DecisionHistory) != 50 {
+		t.Errorf("saved player = %+v, want 50 decisions", player)
+	}
+	world, err := store.LoadWorld()
+	if err != nil {
+		t.Fatalf("failed to load world: %v", err)
+	}
+	if _, exists := world[subZoneKey("centro", "lapa")]; !exists {
+		t.Errorf("world = %+v, want lapa saved in the same write", world)
+	}
+}
@@ -0,0 +1,236 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/user/vida-loka-strategy/internal/types"
+)
+
+// MemoryStore keeps game state in memory only. Nothing survives a restart, so
+// it's meant for tests and throwaway instances.
+type MemoryStore struct {
+	players  map[string][]byte
+	factions map[string][]byte
+	world    map[string][]byte
+	catalog  *types.GameState
+	mu       sync.RWMutex
+}
+
+// Ensure MemoryStore satisfies the StateStore interface
+var _ StateStore = (*MemoryStore)(nil)
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		players:  make(map[string][]byte),
+		factions: make(map[string][]byte),
+		world:    make(map[string][]byte),
+		catalog:  newEmptyGameState(),
+	}
+}
+
+// LoadPlayers returns copies of all saved players keyed by phone number
+func (ms *MemoryStore) LoadPlayers() (map[string]*types.Player, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	players := make(map[string]*types.Player, len(ms.players))
+	for phoneNumber, data := range ms.players {
+		var player types.Player
+		if err := json.Unmarshal(data, &player); err != nil {
+			return nil, fmt.Errorf("failed to parse player %s: %w", phoneNumber, err)
+		}
+		players[phoneNumber] = &player
+	}
+
+	return players, nil
+}
+
+// SavePlayers stores a snapshot of the given players, so later changes to them
+// aren't visible until they are saved again
+func (ms *MemoryStore) SavePlayers(players ...*types.Player) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	for _, player := range players {
+		data, err := json.Marshal(player)
+		if err != nil {
+			return fmt.Errorf("failed to marshal player %s: %w", player.PhoneNumber, err)
+		}
+		ms.players[player.PhoneNumber] = data
+	}
+
+	return nil
+}
+
+// SaveBatch stores a snapshot of everything in the batch; the decision
+// history is saved along with the player
+func (ms *MemoryStore) SaveBatch(batch Batch) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	for _, player := range batch.Players {
+		data, err := json.Marshal(player)
+		if err != nil {
+			return fmt.Errorf("failed to marshal player %s: %w", player.PhoneNumber, err)
+		}
+		ms.players[player.PhoneNumber] = data
+	}
+
+	for _, faction := range batch.Factions {
+		data, err := json.Marshal(faction)
+		if err != nil {
+			return fmt.Errorf("failed to marshal faction %s: %w", faction.ID, err)
+		}
+		ms.factions[faction.ID] = data
+	}
+
+	for _, id := range batch.DeletedFactions {
+		delete(ms.factions, id)
+	}
+
+	for _, state := range batch.World {
+		key := subZoneKey(state.Zone, state.SubZone)
+		data, err := json.Marshal(state)
+		if err != nil {
+			return fmt.Errorf("failed to marshal subzone state %s: %w", key, err)
+		}
+		ms.world[key] = data
+	}
+
+	return nil
+}
+
+// LoadCatalog returns the saved characters, events, actions, zones and evolutions
+func (ms *MemoryStore) LoadCatalog() (*types.GameState, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	catalog := newEmptyGameState()
+	for id, character := range ms.catalog.Characters {
+		catalog.Characters[id] = character
+	}
+	for id, event := range ms.catalog.Events {
+		catalog.Events[id] = event
+	}
+	for id, action := range ms.catalog.Actions {
+		catalog.Actions[id] = action
+	}
+	for id, zone := range ms.catalog.Zones {
+		catalog.Zones[id] = zone
+	}
+	for id, evolution := range ms.catalog.Evolutions {
+		catalog.Evolutions[id] = evolution
+	}
+
+	return catalog, nil
+}
+
+// SaveCatalog keeps the characters, events, actions, zones and evolutions of a state
+func (ms *MemoryStore) SaveCatalog(state *types.GameState) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	catalog := newEmptyGameState()
+	for id, character := range state.Characters {
+		catalog.Characters[id] = character
+	}
+	for id, event := range state.Events {
+		catalog.Events[id] = event
+	}
+	for id, action := range state.Actions {
+		catalog.Actions[id] = action
+	}
+	for id, zone := range state.Zones {
+		catalog.Zones[id] = zone
+	}
+	for id, evolution := range state.Evolutions {
+		catalog.Evolutions[id] = evolution
+	}
+	ms.catalog = catalog
+
+	return nil
+}
+
+// LoadFactions returns copies of all saved factions keyed by ID
+func (ms *MemoryStore) LoadFactions() (map[string]*types.Faction, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	factions := make(map[string]*types.Faction, len(ms.factions))
+	for id, data := range ms.factions {
+		var faction types.Faction
+		if err := json.Unmarshal(data, &faction); err != nil {
+			return nil, fmt.Errorf("failed to parse faction %s: %w", id, err)
+		}
+		factions[id] = &faction
+	}
+
+	return factions, nil
+}
+
+// SaveFactions stores a snapshot of the given factions
+func (ms *MemoryStore) SaveFactions(factions ...*types.Faction) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	for _, faction := range factions {
+		data, err := json.Marshal(faction)
+		if err != nil {
+			return fmt.Errorf("failed to marshal faction %s: %w", faction.ID, err)
+		}
+		ms.factions[faction.ID] = data
+	}
+
+	return nil
+}
+
+// DeleteFaction forgets a disbanded faction
+func (ms *MemoryStore) DeleteFaction(id string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	delete(ms.factions, id)
+	return nil
+}
+
+// LoadWorld returns copies of the saved subzone states keyed by "zone/subzone"
+func (ms *MemoryStore) LoadWorld() (map[string]*types.SubZoneState, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	world := make(map[string]*types.SubZoneState, len(ms.world))
+	for key, data := range ms.world {
+		var state types.SubZoneState
+		if err := json.Unmarshal(data, &state); err != nil {
+			return nil, fmt.Errorf("failed to parse subzone state %s: %w", key, err)
+		}
+		world[key] = &state
+	}
+
+	return world, nil
+}
+
+// SaveWorld stores a snapshot of the given subzone states
+func (ms *MemoryStore) SaveWorld(states ...*types.SubZoneState) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	for _, state := range states {
+		key := subZoneKey(state.Zone, state.SubZone)
+		data, err := json.Marshal(state)
+		if err != nil {
+			return fmt.Errorf("failed to marshal subzone state %s: %w", key, err)
+		}
+		ms.world[key] = data
+	}
+
+	return nil
+}
+
+// Close is a no-op
+func (ms *MemoryStore) Close() error {
+	return nil
+}
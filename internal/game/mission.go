@@ -0,0 +1,777 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/user/vida-loka-strategy/internal/types"
+	"go.uber.org/zap"
+)
+
+// Statuses of a group mission
+const (
+	missionRecruiting = "recruiting"
+	missionVoting     = "voting"
+)
+
+// Choices recorded for the players of a group mission
+const (
+	missionParticipantChoice = "participante"
+	missionTraitorChoice     = "traidor"
+)
+
+// groupMessage is a message waiting to be posted in a group chat
+type groupMessage struct {
+	groupJID string
+	message  string
+}
+
+// LoadMissions loads the group mission templates
+func (gm *GameManager) LoadMissions(missions []*types.Mission) {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	for _, mission := range missions {
+		gm.missions[mission.ID] = mission
+	}
+}
+
+// GetMissions returns the group mission templates sorted by title
+func (gm *GameManager) GetMissions() []*types.Mission {
+	gm.stateLock.RLock()
+	defer gm.stateLock.RUnlock()
+
+	missions := make([]*types.Mission, 0, len(gm.missions))
+	for _, mission := range gm.missions {
+		missions = append(missions, mission)
+	}
+	sort.Slice(missions, func(i, j int) bool {
+		return missions[i].Title < missions[j].Title
+	})
+	return missions
+}
+
+// GetGroupMission returns the mission running in a group chat
+func (gm *GameManager) GetGroupMission(groupJID string) (*types.GroupMission, error) {
+	gm.stateLock.RLock()
+	defer gm.stateLock.RUnlock()
+
+	mission, exists := gm.groupMissions[groupJID]
+	if !exists {
+		return nil, errors.New("nenhuma missão rolando nesse grupo")
+	}
+	return copyGroupMission(mission), nil
+}
+
+// StartGroupMission opens a mission in a group chat, found by ID or title.
+// The player who opens it joins it, and the others have MissionRecruitTime
+// minutes to join before it starts.
+func (gm *GameManager) StartGroupMission(groupJID, phoneNumber, query string) (*types.GroupMission, error) {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	if groupJID == "" {
+		return nil, errors.New("missões só rolam em grupo")
+	}
+
+	if active, exists := gm.groupMissions[groupJID]; exists {
+		return nil, fmt.Errorf("já tem uma missão rolando nesse grupo: %s", active.Mission.Title)
+	}
+
+	player, err := gm.missionPlayer(phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := gm.findMission(query)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	mission := &types.GroupMission{
+		ID:        uuid.New().String(),
+		GroupJID:  groupJID,
+		Mission:   template,
+		Status:    missionRecruiting,
+		StartedBy: player.PhoneNumber,
+		Participants: []types.MissionParticipant{
+			{PhoneNumber: player.PhoneNumber, Name: player.Name},
+		},
+		CreatedAt:  now,
+		DeadlineAt: now.Add(time.Duration(gm.config.Game.MissionRecruitTime) * time.Minute),
+	}
+	gm.groupMissions[groupJID] = mission
+
+	gm.Logger.Info("Group mission opened",
+		zap.String("mission_id", template.ID),
+		zap.String("group", groupJID),
+		zap.String("started_by", player.PhoneNumber))
+
+	return copyGroupMission(mission), nil
+}
+
+// JoinGroupMission adds the player to the mission recruiting in a group chat.
+// A mission that fills up starts right away.
+func (gm *GameManager) JoinGroupMission(groupJID, phoneNumber string) (*types.GroupMission, error) {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	mission, err := gm.recruitingMission(groupJID)
+	if err != nil {
+		return nil, err
+	}
+
+	if missionParticipant(mission, phoneNumber) != nil {
+		return nil, errors.New("você já está nessa missão")
+	}
+
+	player, err := gm.missionPlayer(phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if mission.Mission.MaxPlayers > 0 && len(mission.Participants) >= mission.Mission.MaxPlayers {
+		return nil, errors.New("a missão já está cheia")
+	}
+
+	mission.Participants = append(mission.Participants, types.MissionParticipant{
+		PhoneNumber: player.PhoneNumber,
+		Name:        player.Name,
+	})
+
+	if mission.Mission.MaxPlayers > 0 && len(mission.Participants) == mission.Mission.MaxPlayers {
+		go gm.notifyGroup(groupJID, gm.launchMission(mission, time.Now()))
+	}
+
+	return copyGroupMission(mission), nil
+}
+
+// LaunchGroupMission starts the mission recruiting in a group chat without
+// waiting for the end of recruitment. Only the player who opened it can.
+func (gm *GameManager) LaunchGroupMission(groupJID, phoneNumber string) (*types.GroupMission, error) {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	mission, err := gm.recruitingMission(groupJID)
+	if err != nil {
+		return nil, err
+	}
+
+	if mission.StartedBy != phoneNumber {
+		return nil, errors.New("só quem abriu a missão pode começar")
+	}
+
+	if len(mission.Participants) < mission.Mission.MinPlayers {
+		return nil, fmt.Errorf("a missão precisa de pelo menos %d jogadores", mission.Mission.MinPlayers)
+	}
+
+	go gm.notifyGroup(groupJID, gm.launchMission(mission, time.Now()))
+
+	return copyGroupMission(mission), nil
+}
+
+// VoteInGroupMission records the player's vote on the current step of the
+// mission running in a group chat. The choice is an option ID or letter. The
+// step is resolved as soon as every participant has voted.
+func (gm *GameManager) VoteInGroupMission(groupJID, phoneNumber, choice string) (*types.EventOption, error) {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	mission, participant, err := gm.votingParticipant(groupJID, phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	option := missionOption(mission.Mission.Steps[mission.Step], choice)
+	if option == nil {
+		return nil, fmt.Errorf("opção inválida: %s", choice)
+	}
+	participant.Vote = option.ID
+
+	if allVoted(mission) {
+		go gm.notifyGroup(groupJID, gm.resolveMissionStep(mission, time.Now()))
+	}
+
+	optionCopy := *option
+	return &optionCopy, nil
+}
+
+// BetrayGroupMission makes the player try to run off with half of the pot of
+// the mission running in a group chat, rolling proficiencia against the best
+// rede among the others. Caught or not, the traitor is out of the mission,
+// and whatever they take is lost for everyone else.
+func (gm *GameManager) BetrayGroupMission(groupJID, phoneNumber string) (*types.Outcome, error) {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	mission, participant, err := gm.votingParticipant(groupJID, phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if mission.Pot <= 0 {
+		return nil, errors.New("ainda não tem nada no pote pra levar")
+	}
+
+	player, exists := gm.state.Players[phoneNumber]
+	if !exists {
+		return nil, errors.New("jogador não encontrado")
+	}
+
+	roll := rand.Intn(20) + 1 + playerAttribute(player, "proficiencia") + playerStressBand(player).RollPenalty
+	best := 0
+	for _, other := range activeParticipants(mission) {
+		if other == participant {
+			continue
+		}
+		if p, exists := gm.state.Players[other.PhoneNumber]; exists {
+			if otherRoll := rand.Intn(20) + 1 + playerAttribute(p, "rede") + playerStressBand(p).RollPenalty; otherRoll > best {
+				best = otherRoll
+			}
+		}
+	}
+
+	var outcome types.Outcome
+	if roll > best {
+		stolen := mission.Pot / 2
+		mission.Pot -= stolen
+		outcome = types.Outcome{
+			Description:     fmt.Sprintf("%s meteu o pé com R$ %d,00 do pote! 🐍 (%d x %d)", player.Name, stolen, roll, best),
+			XPChange:        5,
+			MoneyChange:     stolen,
+			InfluenceChange: -5,
+			StressChange:    5,
+		}
+	} else {
+		outcome = types.Outcome{
+			Description:     fmt.Sprintf("A galera pegou %s no pulo e botou pra correr! 😱 (%d x %d)", player.Name, roll, best),
+			InfluenceChange: -10,
+			StressChange:    15,
+		}
+	}
+
+	participant.Betrayed = true
+	participant.Vote = ""
+	now := time.Now()
+	gm.applyMissionOutcome(player, participant, outcome, now)
+	gm.recordMissionDecision(player, mission, participant, missionTraitorChoice, now)
+
+	gm.Logger.Info("Group mission betrayed",
+		zap.String("mission_id", mission.Mission.ID),
+		zap.String("group", groupJID),
+		zap.String("traitor", phoneNumber),
+		zap.Bool("success", roll > best))
+
+	// Whoever is left may have been waiting only on the traitor's vote
+	if len(activeParticipants(mission)) == 0 {
+		go gm.notifyGroup(groupJID, gm.finishMission(mission, now))
+	} else if allVoted(mission) {
+		go gm.notifyGroup(groupJID, gm.resolveMissionStep(mission, now))
+	}
+
+	return &outcome, nil
+}
+
+// missionPlayer returns a player who can take part in a group mission.
+// Callers must hold stateLock.
+func (gm *GameManager) missionPlayer(phoneNumber string) (*types.Player, error) {
+	player, exists := gm.state.Players[phoneNumber]
+	if !exists {
+		return nil, errors.New("jogador não encontrado")
+	}
+
+	if err := checkCanInteract(player, "você"); err != nil {
+		return nil, err
+	}
+
+	for _, mission := range gm.groupMissions {
+		if p := missionParticipant(mission, phoneNumber); p != nil && !p.Betrayed {
+			return nil, errors.New("você já está em uma missão")
+		}
+	}
+
+	return player, nil
+}
+
+// findMission finds a mission template by ID or title, ignoring case.
+// Callers must hold stateLock.
+func (gm *GameManager) findMission(query string) (*types.Mission, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.New("diga qual missão")
+	}
+
+	if mission, exists := gm.missions[query]; exists {
+		return mission, nil
+	}
+
+	for _, mission := range gm.missions {
+		if strings.EqualFold(mission.Title, query) {
+			return mission, nil
+		}
+	}
+
+	return nil, fmt.Errorf("missão não encontrada: %s", query)
+}
+
+// recruitingMission returns the mission recruiting in a group chat. Callers
+// must hold stateLock.
+func (gm *GameManager) recruitingMission(groupJID string) (*types.GroupMission, error) {
+	mission, exists := gm.groupMissions[groupJID]
+	if !exists {
+		return nil, errors.New("nenhuma missão rolando nesse grupo")
+	}
+	if mission.Status != missionRecruiting {
+		return nil, errors.New("a missão já começou")
+	}
+	return mission, nil
+}
+
+// votingParticipant returns the mission voting in a group chat and the
+// player's place in it. Callers must hold stateLock.
+func (gm *GameManager) votingParticipant(groupJID, phoneNumber string) (*types.GroupMission, *types.MissionParticipant, error) {
+	mission, exists := gm.groupMissions[groupJID]
+	if !exists {
+		return nil, nil, errors.New("nenhuma missão rolando nesse grupo")
+	}
+	if mission.Status != missionVoting {
+		return nil, nil, errors.New("a missão ainda não começou")
+	}
+
+	participant := missionParticipant(mission, phoneNumber)
+	if participant == nil || participant.Betrayed {
+		return nil, nil, errors.New("você não está nessa missão")
+	}
+
+	return mission, participant, nil
+}
+
+// launchMission moves a mission from recruitment to its first step and
+// returns the message announcing it. Callers must hold stateLock.
+func (gm *GameManager) launchMission(mission *types.GroupMission, now time.Time) string {
+	mission.Status = missionVoting
+	mission.Step = 0
+	mission.DeadlineAt = now.Add(time.Duration(gm.config.Game.MissionStepTime) * time.Minute)
+
+	gm.Logger.Info("Group mission started",
+		zap.String("mission_id", mission.Mission.ID),
+		zap.String("group", mission.GroupJID),
+		zap.Int("participants", len(mission.Participants)))
+
+	return fmt.Sprintf("🚀 *A MISSÃO COMEÇOU!* 🚀\n\nNa equipe: %s\n\n%s",
+		strings.Join(participantNames(mission), ", "), formatMissionStep(mission, gm.config.Game.MissionStepTime))
+}
+
+// resolveMissionStep plays the current step of a mission with the option most
+// voted for. Everyone who voted rolls the option's attribute, and the group
+// succeeds when the sum reaches its difficulty times the number of rollers.
+// Every participant gets the outcome, and its money goes to the pot. Returns
+// the message announcing the result. Callers must hold stateLock.
+func (gm *GameManager) resolveMissionStep(mission *types.GroupMission, now time.Time) string {
+	step := mission.Mission.Steps[mission.Step]
+	active := activeParticipants(mission)
+	option := mostVoted(step, active)
+
+	// When nobody voted, everyone is dragged along with the draw
+	rollers := make([]*types.MissionParticipant, 0, len(active))
+	for _, participant := range active {
+		if participant.Vote != "" {
+			rollers = append(rollers, participant)
+		}
+	}
+	if len(rollers) == 0 {
+		rollers = active
+	}
+
+	total := 0
+	for _, participant := range rollers {
+		if player, exists := gm.state.Players[participant.PhoneNumber]; exists {
+			total += rand.Intn(20) + 1 + playerAttribute(player, option.RequiredAttribute) + playerStressBand(player).RollPenalty
+		}
+	}
+	required := option.DifficultyLevel * len(rollers)
+	success := total >= required
+
+	outcome := option.FailureOutcome
+	if success {
+		outcome = option.SuccessOutcome
+	}
+
+	mission.Pot += outcome.MoneyChange * len(active)
+	perPlayer := outcome
+	perPlayer.MoneyChange = 0
+	for _, participant := range active {
+		if player, exists := gm.state.Players[participant.PhoneNumber]; exists {
+			gm.applyMissionOutcome(player, participant, perPlayer, now)
+		}
+		participant.Vote = ""
+	}
+
+	gm.Logger.Info("Group mission step resolved",
+		zap.String("mission_id", mission.Mission.ID),
+		zap.String("group", mission.GroupJID),
+		zap.Int("step", mission.Step+1),
+		zap.String("option", option.ID),
+		zap.Int("total", total),
+		zap.Int("required", required),
+		zap.Bool("success", success))
+
+	result := "❌ Deu ruim!"
+	if success {
+		result = "✅ Deu bom!"
+	}
+	message := fmt.Sprintf("🎲 *ETAPA %d* 🎲\n\nA galera foi de: *%s*\n🎲 Rolagem do grupo: %d x %d — %s\n\n%s%s\n💰 Pote: R$ %d,00",
+		mission.Step+1, option.Description, total, required, result,
+		outcome.Description, formatOutcomeChanges(&perPlayer), mission.Pot)
+
+	mission.Step++
+	if mission.Step >= len(mission.Mission.Steps) {
+		return message + "\n\n" + gm.finishMission(mission, now)
+	}
+
+	mission.DeadlineAt = now.Add(time.Duration(gm.config.Game.MissionStepTime) * time.Minute)
+	return message + "\n\n" + formatMissionStep(mission, gm.config.Game.MissionStepTime)
+}
+
+// finishMission splits the pot among the participants who stayed to the
+// end, records the mission in their decision histories and closes it.
+// Returns the summary of the mission. Callers must hold stateLock.
+func (gm *GameManager) finishMission(mission *types.GroupMission, now time.Time) string {
+	delete(gm.groupMissions, mission.GroupJID)
+
+	active := activeParticipants(mission)
+	share := 0
+	if len(active) > 0 {
+		share = mission.Pot / len(active)
+	}
+
+	for _, participant := range active {
+		player, exists := gm.state.Players[participant.PhoneNumber]
+		if !exists {
+			continue
+		}
+		gm.applyMissionOutcome(player, participant, types.Outcome{MoneyChange: share}, now)
+		gm.recordMissionDecision(player, mission, participant, missionParticipantChoice, now)
+	}
+
+	gm.Logger.Info("Group mission finished",
+		zap.String("mission_id", mission.Mission.ID),
+		zap.String("group", mission.GroupJID),
+		zap.Int("pot", mission.Pot),
+		zap.Int("share", share))
+
+	message := fmt.Sprintf("🏁 *MISSÃO CONCLUÍDA: %s* 🏁\n\nPote final: R$ %d,00", strings.ToUpper(mission.Mission.Title), mission.Pot)
+	if len(active) > 0 {
+		message += fmt.Sprintf(" (R$ %d,00 pra cada)", share)
+	}
+	message += "\n"
+
+	for _, participant := range mission.Participants {
+		o := participant.Outcome
+		line := fmt.Sprintf("\n*%s*: ⭐ %+d XP · 💰 R$ %+d,00 · 🎭 %+d · 💥 %+d",
+			participant.Name, o.XPChange, o.MoneyChange, o.InfluenceChange, o.StressChange)
+		if participant.Betrayed {
+			line += " 🐍"
+		}
+		message += line
+	}
+
+	return message
+}
+
+// cancelMission closes a mission that didn't get enough players. Callers must
+// hold stateLock.
+func (gm *GameManager) cancelMission(mission *types.GroupMission) string {
+	delete(gm.groupMissions, mission.GroupJID)
+
+	gm.Logger.Info("Group mission cancelled",
+		zap.String("mission_id", mission.Mission.ID),
+		zap.String("group", mission.GroupJID),
+		zap.Int("participants", len(mission.Participants)))
+
+	return fmt.Sprintf("😴 A missão *%s* foi cancelada: precisava de pelo menos %d jogadores.",
+		mission.Mission.Title, mission.Mission.MinPlayers)
+}
+
+// advanceMissions moves on every mission past its deadline: recruitments end
+// with the mission starting or being cancelled, and steps are resolved with
+// the votes cast so far. Returns the messages for the groups.
+func (gm *GameManager) advanceMissions(now time.Time) []groupMessage {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	var messages []groupMessage
+	for groupJID, mission := range gm.groupMissions {
+		if now.Before(mission.DeadlineAt) {
+			continue
+		}
+
+		var message string
+		switch {
+		case mission.Status == missionVoting:
+			message = gm.resolveMissionStep(mission, now)
+		case len(mission.Participants) >= mission.Mission.MinPlayers:
+			message = gm.launchMission(mission, now)
+		default:
+			message = gm.cancelMission(mission)
+		}
+		messages = append(messages, groupMessage{groupJID: groupJID, message: message})
+	}
+
+	return messages
+}
+
+// EndGroupMissions closes every group mission before a shutdown, since they
+// live only in memory: missions underway pay out the pot as it stands, and
+// recruitments are called off. The news is posted in the groups.
+func (gm *GameManager) EndGroupMissions() {
+	for _, m := range gm.endGroupMissions(time.Now()) {
+		gm.notifyGroup(m.groupJID, m.message)
+	}
+}
+
+// endGroupMissions closes every group mission and returns the messages for
+// the groups
+func (gm *GameManager) endGroupMissions(now time.Time) []groupMessage {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	var messages []groupMessage
+	for groupJID, mission := range gm.groupMissions {
+		var message string
+		if mission.Status == missionVoting {
+			message = "⏹️ O jogo vai reiniciar, então a missão termina por aqui.\n\n" + gm.finishMission(mission, now)
+		} else {
+			delete(gm.groupMissions, groupJID)
+
+			gm.Logger.Info("Group mission called off for shutdown",
+				zap.String("mission_id", mission.Mission.ID),
+				zap.String("group", groupJID))
+
+			message = fmt.Sprintf("😴 A missão *%s* foi cancelada: o jogo vai reiniciar. Chamem a galera de novo daqui a pouco!",
+				mission.Mission.Title)
+		}
+		messages = append(messages, groupMessage{groupJID: groupJID, message: message})
+	}
+
+	return messages
+}
+
+// applyMissionOutcome applies part of a mission to a player and adds it to
+// their participation. Callers must hold stateLock.
+func (gm *GameManager) applyMissionOutcome(player *types.Player, participant *types.MissionParticipant, outcome types.Outcome, now time.Time) {
+	player.XP += outcome.XPChange
+	player.Money += outcome.MoneyChange
+	player.Influence += outcome.InfluenceChange
+	player.Stress += outcome.StressChange
+
+	participant.Outcome.XPChange += outcome.XPChange
+	participant.Outcome.MoneyChange += outcome.MoneyChange
+	participant.Outcome.InfluenceChange += outcome.InfluenceChange
+	participant.Outcome.StressChange += outcome.StressChange
+
+	// Keep stress within bounds and burn out (or recover) the player
+	gm.updateBurnout(player)
+
+	// New XP may mean a new level
+	gm.checkLevelUp(player)
+
+	// Progress may also complete an evolution path
+	gm.checkEvolution(player)
+
+	player.LastActiveAt = now
+
+	// Queue the player for the next save
+	gm.markDirty(player)
+}
+
+// recordMissionDecision records a player's whole participation in a mission
+// in their decision history. Callers must hold stateLock.
+func (gm *GameManager) recordMissionDecision(player *types.Player, mission *types.GroupMission, participant *types.MissionParticipant, choice string, now time.Time) {
+	o := participant.Outcome
+	gm.recordDecision(player, types.Decision{
+		ID:              uuid.New().String(),
+		EventID:         "mission_" + mission.Mission.ID,
+		Choice:          choice,
+		Timestamp:       now,
+		Outcome:         fmt.Sprintf("Missão %s: %d etapas, pote R$ %d,00", mission.Mission.Title, mission.Step, mission.Pot),
+		XPChange:        o.XPChange,
+		MoneyChange:     o.MoneyChange,
+		InfluenceChange: o.InfluenceChange,
+		StressChange:    o.StressChange,
+	})
+}
+
+// notifyGroup posts an unsolicited message in a group chat, logging failures
+func (gm *GameManager) notifyGroup(groupJID, message string) {
+	if err := gm.SendGroupMessage(groupJID, message); err != nil {
+		gm.Logger.Warn("Failed to notify group",
+			zap.String("group", groupJID),
+			zap.Error(err))
+	}
+}
+
+// missionParticipant returns the player's place in a mission, or nil
+func missionParticipant(mission *types.GroupMission, phoneNumber string) *types.MissionParticipant {
+	for i := range mission.Participants {
+		if mission.Participants[i].PhoneNumber == phoneNumber {
+			return &mission.Participants[i]
+		}
+	}
+	return nil
+}
+
+// activeParticipants returns the participants who haven't betrayed the
+// mission
+func activeParticipants(mission *types.GroupMission) []*types.MissionParticipant {
+	var active []*types.MissionParticipant
+	for i := range mission.Participants {
+		if !mission.Participants[i].Betrayed {
+			active = append(active, &mission.Participants[i])
+		}
+	}
+	return active
+}
+
+// participantNames returns the names of the active participants
+func participantNames(mission *types.GroupMission) []string {
+	var names []string
+	for _, participant := range activeParticipants(mission) {
+		names = append(names, participant.Name)
+	}
+	return names
+}
+
+// allVoted reports whether every active participant voted on the current step
+func allVoted(mission *types.GroupMission) bool {
+	for _, participant := range activeParticipants(mission) {
+		if participant.Vote == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// missionOption finds an option of a step by ID or letter
+func missionOption(step types.MissionStep, choice string) *types.EventOption {
+	choice = strings.ToLower(strings.TrimSpace(choice))
+	for i := range step.Options {
+		if step.Options[i].ID == choice {
+			return &step.Options[i]
+		}
+	}
+	if len(choice) == 1 {
+		if i := int(choice[0] - 'a'); i >= 0 && i < len(step.Options) {
+			return &step.Options[i]
+		}
+	}
+	return nil
+}
+
+// mostVoted returns the option of a step with the most votes, drawing among
+// the tied ones
+func mostVoted(step types.MissionStep, participants []*types.MissionParticipant) *types.EventOption {
+	votes := make(map[string]int)
+	for _, participant := range participants {
+		if participant.Vote != "" {
+			votes[participant.Vote]++
+		}
+	}
+
+	var tied []*types.EventOption
+	most := -1
+	for i := range step.Options {
+		count := votes[step.Options[i].ID]
+		if count > most {
+			most = count
+			tied = tied[:0]
+		}
+		if count == most {
+			tied = append(tied, &step.Options[i])
+		}
+	}
+
+	return tied[rand.Intn(len(tied))]
+}
+
+// copyGroupMission returns a copy of a mission that is safe to read without
+// holding stateLock
+func copyGroupMission(mission *types.GroupMission) *types.GroupMission {
+	missionCopy := *mission
+	missionCopy.Participants = append([]types.MissionParticipant(nil), mission.Participants...)
+	return &missionCopy
+}
+
+// formatMissionStep announces the current step of a mission to its group
+func formatMissionStep(mission *types.GroupMission, timeout int) string {
+	step := mission.Mission.Steps[mission.Step]
+
+	message := fmt.Sprintf("🎯 *%s* — Etapa %d/%d 🎯\n\n%s\n\nVotem:\n",
+		strings.ToUpper(mission.Mission.Title), mission.Step+1, len(mission.Mission.Steps), step.Description)
+	for i, option := range step.Options {
+		message += fmt.Sprintf("%s. %s (%s)\n", string(rune('A'+i)), option.Description, option.RequiredAttribute)
+	}
+
+	return message + fmt.Sprintf("\nResponda com */ votar [letra]* (vale por %d min). Ou */ trair* se tiver coragem... 🐍", timeout)
+}
+
+// MissionSystem moves group missions on when their deadlines pass
+type MissionSystem struct {
+	gameManager *GameManager
+	ticker      *time.Ticker
+	stopChan    chan struct{}
+	doneChan    chan struct{}
+	logger      *zap.Logger
+}
+
+// NewMissionSystem creates a new mission system
+func NewMissionSystem(gameManager *GameManager, checkInterval time.Duration, logger *zap.Logger) *MissionSystem {
+	return &MissionSystem{
+		gameManager: gameManager,
+		ticker:      time.NewTicker(checkInterval),
+		stopChan:    make(chan struct{}),
+		doneChan:    make(chan struct{}),
+		logger:      logger,
+	}
+}
+
+// Start begins the mission system
+func (ms *MissionSystem) Start() {
+	go func() {
+		defer close(ms.doneChan)
+
+		for {
+			select {
+			case <-ms.ticker.C:
+				ms.processDeadlines()
+			case <-ms.stopChan:
+				ms.ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the mission system, waiting for a running check to finish
+func (ms *MissionSystem) Stop() {
+	close(ms.stopChan)
+	<-ms.doneChan
+}
+
+// processDeadlines moves on the missions that are due and posts the news in
+// their groups
+func (ms *MissionSystem) processDeadlines() {
+	for _, m := range ms.gameManager.advanceMissions(time.Now()) {
+		if err := ms.gameManager.SendGroupMessage(m.groupJID, m.message); err != nil {
+			ms.logger.Error("Failed to send mission message",
+				zap.String("group", m.groupJID),
+				zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,395 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/user/vida-loka-strategy/internal/types"
+	"go.uber.org/zap"
+)
+
+// Message categories players can turn off. Messages without a category, such
+// as level ups or faction news, can't be turned off but still wait for the
+// end of quiet hours.
+const (
+	categoryGeneral = ""
+	categoryEvents  = "eventos"
+	categoryNews    = "noticias"
+	categoryPvP     = "pvp"
+)
+
+// notificationCategories lists the categories players can turn off
+var notificationCategories = []string{categoryEvents, categoryNews, categoryPvP}
+
+// Event frequencies; each one scales the time between a player's event checks
+const (
+	frequencyLow    = "baixa"
+	frequencyNormal = "normal"
+	frequencyHigh   = "alta"
+)
+
+// inQuietHours reports whether the time falls in the player's quiet hours
+func inQuietHours(settings *types.NotificationSettings, now time.Time) bool {
+	if settings == nil || settings.QuietHours == nil {
+		return false
+	}
+
+	hour := now.Hour()
+	start, end := settings.QuietHours.Start, settings.QuietHours.End
+	if start > end {
+		return hour >= start || hour < end
+	}
+	return hour >= start && hour < end
+}
+
+// isMuted reports whether the player turned a category of messages off
+func isMuted(settings *types.NotificationSettings, category string) bool {
+	return settings != nil && category != categoryGeneral && containsString(settings.Muted, category)
+}
+
+// scaleByFrequency stretches or shortens the time between event checks by the
+// player's chosen frequency
+func scaleByFrequency(interval time.Duration, settings *types.NotificationSettings) time.Duration {
+	if settings == nil {
+		return interval
+	}
+
+	switch settings.Frequency {
+	case frequencyLow:
+		return interval * 2
+	case frequencyHigh:
+		return interval / 2
+	}
+	return interval
+}
+
+// sendNotification sends a message the game sends on its own, honoring the
+// player's settings: muted categories are skipped and messages during quiet
+// hours are kept for later
+func (gm *GameManager) sendNotification(playerID, category, message string) error {
+	phoneNumber, deliver, err := gm.routeNotification(playerID, category, message, time.Now())
+	if err != nil || !deliver {
+		return err
+	}
+
+	return gm.deliverMessage(phoneNumber, message)
+}
+
+// routeNotification decides what happens to a notification. It returns the
+// player's phone number and whether the message should go out now.
+func (gm *GameManager) routeNotification(playerID, category, message string, now time.Time) (string, bool, error) {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	var player *types.Player
+	for _, p := range gm.state.Players {
+		if p.ID == playerID || p.PhoneNumber == playerID {
+			player = p
+			break
+		}
+	}
+
+	if player == nil {
+		return "", false, fmt.Errorf("jogador não encontrado: %s", playerID)
+	}
+
+	if isMuted(player.Notifications, category) {
+		gm.Logger.Debug("Skipping muted notification",
+			zap.String("phone_number", player.PhoneNumber),
+			zap.String("category", category))
+		return player.PhoneNumber, false, nil
+	}
+
+	if inQuietHours(player.Notifications, now) {
+		player.Notifications.Deferred = append(player.Notifications.Deferred, types.DeferredMessage{
+			Category: category,
+			Message:  message,
+			QueuedAt: now,
+		})
+
+		// Queue the player for the next save
+		gm.markDirty(player)
+
+		return player.PhoneNumber, false, nil
+	}
+
+	return player.PhoneNumber, true, nil
+}
+
+// deliverMessage sends a message to a phone number through the bot
+func (gm *GameManager) deliverMessage(phoneNumber, message string) error {
+	if gm.messageSender == nil {
+		return fmt.Errorf("message sender not set")
+	}
+
+	// Get the bot's phone number from the client manager
+	if gm.clientManager == nil {
+		return fmt.Errorf("client manager not set")
+	}
+
+	botPhoneNumber, err := gm.clientManager.GetBotPhoneNumber()
+	if err != nil {
+		return fmt.Errorf("failed to get bot phone number: %w", err)
+	}
+
+	// Send message through message sender using the bot's phone number
+	if _, err := gm.messageSender.SendMessage(botPhoneNumber, phoneNumber, message); err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+
+	return nil
+}
+
+// notifyPlayerAbout sends an unsolicited message of a category to a player,
+// logging failures
+func (gm *GameManager) notifyPlayerAbout(phoneNumber, category, message string) {
+	if err := gm.sendNotification(phoneNumber, category, message); err != nil {
+		gm.Logger.Warn("Failed to notify player",
+			zap.String("phone_number", phoneNumber),
+			zap.String("category", category),
+			zap.Error(err))
+	}
+}
+
+// deferredDelivery is the messages a player missed during quiet hours
+type deferredDelivery struct {
+	phoneNumber string
+	messages    []types.DeferredMessage
+}
+
+// releaseDeferred takes the messages held for players whose quiet hours are
+// over. Callers hand back the ones they fail to deliver with requeueDeferred.
+func (gm *GameManager) releaseDeferred(now time.Time) []deferredDelivery {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	var deliveries []deferredDelivery
+	for _, player := range gm.state.Players {
+		settings := player.Notifications
+		if settings == nil || len(settings.Deferred) == 0 || inQuietHours(settings, now) {
+			continue
+		}
+
+		deliveries = append(deliveries, deferredDelivery{phoneNumber: player.PhoneNumber, messages: settings.Deferred})
+		settings.Deferred = nil
+
+		// Queue the player for the next save
+		gm.markDirty(player)
+	}
+
+	return deliveries
+}
+
+// requeueDeferred puts back messages that couldn't be delivered, ahead of
+// anything held since, so they go out on the next delivery
+func (gm *GameManager) requeueDeferred(phoneNumber string, messages []types.DeferredMessage) {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	player, exists := gm.state.Players[phoneNumber]
+	if !exists {
+		return
+	}
+
+	settings := notificationSettings(player)
+	settings.Deferred = append(append([]types.DeferredMessage(nil), messages...), settings.Deferred...)
+
+	// Queue the player for the next save
+	gm.markDirty(player)
+}
+
+// notificationSettings returns the player's settings, creating them on first
+// use. Callers must hold stateLock.
+func notificationSettings(player *types.Player) *types.NotificationSettings {
+	if player.Notifications == nil {
+		player.Notifications = &types.NotificationSettings{}
+	}
+	return player.Notifications
+}
+
+// GetNotificationSettings returns a copy of the player's notification settings
+func (gm *GameManager) GetNotificationSettings(phoneNumber string) (*types.NotificationSettings, error) {
+	gm.stateLock.RLock()
+	defer gm.stateLock.RUnlock()
+
+	player, exists := gm.state.Players[phoneNumber]
+	if !exists {
+		return nil, errors.New("jogador não encontrado")
+	}
+
+	settings := types.NotificationSettings{Frequency: frequencyNormal}
+	if player.Notifications != nil {
+		settings = *player.Notifications
+		if settings.Frequency == "" {
+			settings.Frequency = frequencyNormal
+		}
+		if settings.QuietHours != nil {
+			quiet := *settings.QuietHours
+			settings.QuietHours = &quiet
+		}
+		settings.Muted = append([]string(nil), settings.Muted...)
+		settings.Deferred = append([]types.DeferredMessage(nil), settings.Deferred...)
+	}
+
+	return &settings, nil
+}
+
+// SetQuietHours sets the hours the player doesn't want to be messaged in;
+// nil turns quiet hours off, and what was held goes out on the next delivery
+func (gm *GameManager) SetQuietHours(phoneNumber string, hours *types.QuietHours) error {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	player, exists := gm.state.Players[phoneNumber]
+	if !exists {
+		return errors.New("jogador não encontrado")
+	}
+
+	if hours != nil {
+		if hours.Start < 0 || hours.Start > 23 || hours.End < 0 || hours.End > 23 {
+			return errors.New("as horas vão de 0 a 23")
+		}
+		if hours.Start == hours.End {
+			return errors.New("o silêncio precisa começar e terminar em horas diferentes")
+		}
+		quiet := *hours
+		hours = &quiet
+	}
+
+	notificationSettings(player).QuietHours = hours
+
+	// Queue the player for the next save
+	gm.markDirty(player)
+
+	return nil
+}
+
+// SetNotificationFrequency sets how often the player gets random events
+func (gm *GameManager) SetNotificationFrequency(phoneNumber, frequency string) error {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	player, exists := gm.state.Players[phoneNumber]
+	if !exists {
+		return errors.New("jogador não encontrado")
+	}
+
+	frequency = strings.ToLower(frequency)
+	if frequency != frequencyLow && frequency != frequencyNormal && frequency != frequencyHigh {
+		return fmt.Errorf("frequência inválida, escolha entre %s, %s ou %s", frequencyLow, frequencyNormal, frequencyHigh)
+	}
+
+	notificationSettings(player).Frequency = frequency
+
+	// The new frequency counts from the next scheduled check on
+	gm.markDirty(player)
+
+	return nil
+}
+
+// SetNotificationCategory turns a category of messages on or off
+func (gm *GameManager) SetNotificationCategory(phoneNumber, category string, enabled bool) error {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	player, exists := gm.state.Players[phoneNumber]
+	if !exists {
+		return errors.New("jogador não encontrado")
+	}
+
+	category = strings.ToLower(category)
+	if !containsString(notificationCategories, category) {
+		return fmt.Errorf("categoria inválida, escolha entre %s", strings.Join(notificationCategories, ", "))
+	}
+
+	settings := notificationSettings(player)
+	muted := make([]string, 0, len(settings.Muted))
+	for _, c := range settings.Muted {
+		if c != category {
+			muted = append(muted, c)
+		}
+	}
+	if !enabled {
+		muted = append(muted, category)
+	}
+	settings.Muted = muted
+
+	// Queue the player for the next save
+	gm.markDirty(player)
+
+	return nil
+}
+
+// formatDeferredMessages joins the messages a player missed into one
+func formatDeferredMessages(messages []types.DeferredMessage) string {
+	parts := make([]string, len(messages))
+	for i, m := range messages {
+		parts[i] = m.Message
+	}
+
+	return "🌅 *ENQUANTO VOCÊ DESCANSAVA...* 🌅\n\n" + strings.Join(parts, "\n\n➖➖➖\n\n")
+}
+
+// NotificationSystem delivers the messages held during quiet hours once they
+// are over
+type NotificationSystem struct {
+	gameManager *GameManager
+	ticker      *time.Ticker
+	stopChan    chan struct{}
+	doneChan    chan struct{}
+	logger      *zap.Logger
+}
+
+// NewNotificationSystem creates a new notification system
+func NewNotificationSystem(gameManager *GameManager, checkInterval time.Duration, logger *zap.Logger) *NotificationSystem {
+	return &NotificationSystem{
+		gameManager: gameManager,
+		ticker:      time.NewTicker(checkInterval),
+		stopChan:    make(chan struct{}),
+		doneChan:    make(chan struct{}),
+		logger:      logger,
+	}
+}
+
+// Start begins the notification system
+func (ns *NotificationSystem) Start() {
+	go func() {
+		defer close(ns.doneChan)
+
+		for {
+			select {
+			case <-ns.ticker.C:
+				ns.deliverDeferred()
+			case <-ns.stopChan:
+				ns.ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the notification system, waiting for a running delivery to
+// finish. Held messages are saved with the player and sent after a restart.
+func (ns *NotificationSystem) Stop() {
+	close(ns.stopChan)
+	<-ns.doneChan
+}
+
+// deliverDeferred sends each player whose quiet hours are over everything
+// held for them, in one message. Messages that fail to go out are held again.
+func (ns *NotificationSystem) deliverDeferred() {
+	for _, d := range ns.gameManager.releaseDeferred(time.Now()) {
+		ns.logger.Info("Delivering deferred messages",
+			zap.String("phone_number", d.phoneNumber),
+			zap.Int("messages", len(d.messages)))
+
+		if err := ns.gameManager.deliverMessage(d.phoneNumber, formatDeferredMessages(d.messages)); err != nil {
+			ns.logger.Error("Failed to deliver deferred messages",
+				zap.String("phone_number", d.phoneNumber),
+				zap.Error(err))
+			ns.gameManager.requeueDeferred(d.phoneNumber, d.messages)
+		}
+	}
+}
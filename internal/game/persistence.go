@@ -0,0 +1,62 @@
+package game
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// PersistenceSystem periodically writes the players marked dirty by the
+// GameManager, coalescing bursts of changes into a single store write
+type PersistenceSystem struct {
+	gameManager *GameManager
+	ticker      *time.Ticker
+	stopChan    chan struct{}
+	doneChan    chan struct{}
+	logger      *zap.Logger
+}
+
+// NewPersistenceSystem creates a new persistence system
+func NewPersistenceSystem(gameManager *GameManager, flushInterval time.Duration, logger *zap.Logger) *PersistenceSystem {
+	return &PersistenceSystem{
+		gameManager: gameManager,
+		ticker:      time.NewTicker(flushInterval),
+		stopChan:    make(chan struct{}),
+		doneChan:    make(chan struct{}),
+		logger:      logger,
+	}
+}
+
+// Start begins the persistence system
+func (ps *PersistenceSystem) Start() {
+	go func() {
+		defer close(ps.doneChan)
+
+		for {
+			select {
+			case <-ps.ticker.C:
+				ps.flush()
+			case <-ps.gameManager.flushSignal:
+				// Enough changes piled up to save before the next tick
+				ps.flush()
+			case <-ps.stopChan:
+				ps.ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the persistence system, waiting for a running flush to finish.
+// Pending changes are kept until the next Flush.
+func (ps *PersistenceSystem) Stop() {
+	close(ps.stopChan)
+	<-ps.doneChan
+}
+
+// flush writes pending changes, logging failures since they are retried on the next flush
+func (ps *PersistenceSystem) flush() {
+	if err := ps.gameManager.Flush(); err != nil {
+		ps.logger.Error("Failed to save game state", zap.Error(err))
+	}
+}
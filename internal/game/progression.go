@@ -0,0 +1,164 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/user/vida-loka-strategy/internal/types"
+)
+
+// attributeNames lists the attributes in display order
+var attributeNames = []string{"carisma", "proficiencia", "rede", "moralidade", "resiliencia"}
+
+// newStats starts a player's stats from a character template
+func newStats(character *types.Character) *types.Stats {
+	return &types.Stats{
+		Level:        1,
+		Carisma:      character.Carisma,
+		Proficiencia: character.Proficiencia,
+		Rede:         character.Rede,
+		Moralidade:   character.Moralidade,
+		Resiliencia:  character.Resiliencia,
+	}
+}
+
+// initialStats returns the stats of a player picking a character with the
+// given XP: the character's attributes, the level that XP is worth and the
+// points that level would have granted
+func (gm *GameManager) initialStats(character *types.Character, xp int) *types.Stats {
+	stats := newStats(character)
+	stats.Level = gm.levelForXP(xp)
+	stats.AttributePoints = (stats.Level - 1) * gm.config.Game.AttributePointsPerLevel
+	return stats
+}
+
+// statsAttribute returns a pointer to the named attribute, or nil if there is none
+func statsAttribute(stats *types.Stats, name string) *int {
+	switch name {
+	case "carisma":
+		return &stats.Carisma
+	case "proficiencia":
+		return &stats.Proficiencia
+	case "rede":
+		return &stats.Rede
+	case "moralidade":
+		return &stats.Moralidade
+	case "resiliencia":
+		return &stats.Resiliencia
+	}
+	return nil
+}
+
+// playerAttribute returns the player's value for an attribute, 0 if unknown
+func playerAttribute(player *types.Player, name string) int {
+	if player.Stats == nil {
+		return 0
+	}
+	if value := statsAttribute(player.Stats, name); value != nil {
+		return *value
+	}
+	return 0
+}
+
+// statsAttributes returns all attributes of a stats block by name
+func statsAttributes(stats *types.Stats) map[string]int {
+	attributes := make(map[string]int, len(attributeNames))
+	for _, name := range attributeNames {
+		attributes[name] = *statsAttribute(stats, name)
+	}
+	return attributes
+}
+
+// levelForXP returns the level reached with the given XP
+func (gm *GameManager) levelForXP(xp int) int {
+	level := 1
+	for _, threshold := range gm.config.Game.LevelXPThresholds {
+		if xp >= threshold {
+			level++
+		}
+	}
+	return level
+}
+
+// nextLevelXP returns the XP needed for the level after the given one, or 0
+// at the top level
+func (gm *GameManager) nextLevelXP(level int) int {
+	if level-1 < len(gm.config.Game.LevelXPThresholds) {
+		return gm.config.Game.LevelXPThresholds[level-1]
+	}
+	return 0
+}
+
+// checkLevelUp raises the player's level to match their XP, granting attribute
+// points for each new level, and tells them about it. Levels are never lost
+// when XP goes down. Callers must hold stateLock.
+func (gm *GameManager) checkLevelUp(player *types.Player) {
+	if player.Stats == nil {
+		return
+	}
+
+	level := gm.levelForXP(player.XP)
+	if level <= player.Stats.Level {
+		return
+	}
+
+	gained := level - player.Stats.Level
+	player.Stats.Level = level
+	player.Stats.AttributePoints += gained * gm.config.Game.AttributePointsPerLevel
+
+	message := fmt.Sprintf("🆙 *SUBIU DE NÍVEL!* 🆙\n\n"+
+		"Você chegou no *nível %d*! 🎉\n"+
+		"Pontos de atributo para gastar: *%d*\n\n"+
+		"Use */evoluir* pra ficar mais forte! 💪",
+		level, player.Stats.AttributePoints)
+
+	// Don't hold the state lock while the message goes out
+	go gm.notifyPlayerAbout(player.PhoneNumber, categoryGeneral, message)
+}
+
+// SpendAttributePoints raises one of the player's attributes using their
+// unspent attribute points
+func (gm *GameManager) SpendAttributePoints(phoneNumber, attribute string, points int) (*types.Stats, error) {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	player, exists := gm.state.Players[phoneNumber]
+	if !exists {
+		return nil, errors.New("jogador não encontrado")
+	}
+
+	if player.CurrentCharacter == nil || player.Stats == nil {
+		return nil, errors.New("jogador não selecionou um personagem")
+	}
+
+	value := statsAttribute(player.Stats, attribute)
+	if value == nil {
+		return nil, fmt.Errorf("atributo inválido: %s", attribute)
+	}
+
+	if points <= 0 {
+		return nil, errors.New("quantidade de pontos inválida")
+	}
+
+	if points > player.Stats.AttributePoints {
+		return nil, fmt.Errorf("pontos insuficientes: você tem %d", player.Stats.AttributePoints)
+	}
+
+	if max := gm.config.Game.MaxAttributeValue; max > 0 && *value+points > max {
+		return nil, fmt.Errorf("o máximo de um atributo é %d", max)
+	}
+
+	*value += points
+	player.Stats.AttributePoints -= points
+	player.LastActiveAt = time.Now()
+
+	// A stronger attribute may open an evolution path
+	gm.checkEvolution(player)
+
+	// Queue the player for the next save
+	gm.markDirty(player)
+
+	statsCopy := *player.Stats
+	return &statsCopy, nil
+}
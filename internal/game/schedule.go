@@ -0,0 +1,127 @@
+package game
+
+import (
+	"container/heap"
+	"time"
+)
+
+// maxIdleScale caps how much longer players who stopped playing wait between
+// event checks, as a multiple of the event interval
+const maxIdleScale = 4
+
+// scheduledCheck is a player's next event check in the event queue
+type scheduledCheck struct {
+	phoneNumber string
+	at          time.Time
+	index       int
+}
+
+// eventQueue is a min-heap of event checks ordered by when they are due
+type eventQueue []*scheduledCheck
+
+func (q eventQueue) Len() int           { return len(q) }
+func (q eventQueue) Less(i, j int) bool { return q[i].at.Before(q[j].at) }
+
+func (q eventQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *eventQueue) Push(x interface{}) {
+	check := x.(*scheduledCheck)
+	check.index = len(*q)
+	*q = append(*q, check)
+}
+
+func (q *eventQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	check := old[n-1]
+	old[n-1] = nil
+	check.index = -1
+	*q = old[:n-1]
+	return check
+}
+
+// scheduleNextEvent works out when the player's next event check is due and
+// saves it on the player, so the schedule survives a restart. The check comes
+// an event interval after their last event, scaled by the player's chosen
+// frequency, stretched for players who have been away for days and moved by
+// up to event_jitter percent either way so players don't all roll at once.
+// It returns the zero time for unknown players.
+func (gm *GameManager) scheduleNextEvent(phoneNumber string, now time.Time) time.Time {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	player, exists := gm.state.Players[phoneNumber]
+	if !exists {
+		return time.Time{}
+	}
+
+	interval := scaleByFrequency(time.Duration(gm.config.Game.EventInterval)*time.Minute, player.Notifications)
+
+	// Every day away makes the wait one interval longer
+	if idleDays := int(now.Sub(player.LastActiveAt).Hours() / 24); idleDays > 0 {
+		interval *= time.Duration(min(1+idleDays, maxIdleScale))
+	}
+
+	if jitter := gm.config.Game.EventJitter; jitter > 0 {
+		percent := gm.diceRoller.Roll(2*jitter+1) - jitter - 1
+		interval += interval * time.Duration(percent) / 100
+	}
+
+	next := player.LastEventAt.Add(interval)
+	if next.Before(now) {
+		next = now.Add(interval)
+	}
+
+	player.NextEventAt = next
+
+	// Queue the player for the next save
+	gm.markDirty(player)
+
+	return next
+}
+
+// schedule puts a player's next check in the queue, replacing the one they
+// already had
+func (es *EventSystem) schedule(phoneNumber string, at time.Time) {
+	if check, exists := es.scheduled[phoneNumber]; exists {
+		check.at = at
+		heap.Fix(&es.queue, check.index)
+		return
+	}
+
+	check := &scheduledCheck{phoneNumber: phoneNumber, at: at}
+	heap.Push(&es.queue, check)
+	es.scheduled[phoneNumber] = check
+}
+
+// syncSchedule queues the players who aren't in the queue yet, such as new
+// players or everyone after a restart. Players keep their saved next check;
+// those who never had one get a fresh one.
+func (es *EventSystem) syncSchedule(now time.Time) {
+	for _, player := range es.gameManager.GetAllPlayers() {
+		if _, exists := es.scheduled[player.PhoneNumber]; exists {
+			continue
+		}
+
+		at := player.NextEventAt
+		if at.IsZero() {
+			at = es.gameManager.scheduleNextEvent(player.PhoneNumber, now)
+		}
+		es.schedule(player.PhoneNumber, at)
+	}
+}
+
+// popDue takes the next check off the queue if it is due
+func (es *EventSystem) popDue(now time.Time) (string, bool) {
+	if es.queue.Len() == 0 || es.queue[0].at.After(now) {
+		return "", false
+	}
+
+	check := heap.Pop(&es.queue).(*scheduledCheck)
+	delete(es.scheduled, check.phoneNumber)
+	return check.phoneNumber, true
+}
@@ -0,0 +1,410 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/user/vida-loka-strategy/internal/types"
+	"go.uber.org/zap"
+)
+
+// seasonalEventType marks events only drawn while one of their seasons is on
+const seasonalEventType = "seasonal"
+
+// anchorEaster anchors a season to Easter Sunday, which Carnaval hangs off
+const anchorEaster = "pascoa"
+
+// activeSeason is a season going on and the window it is in
+type activeSeason struct {
+	season *types.Season
+	start  time.Time
+	end    time.Time
+}
+
+// key identifies this occurrence of the season, so players are told about
+// each Carnaval once
+func (a activeSeason) key() string {
+	return a.season.ID + ":" + a.start.Format("2006-01-02")
+}
+
+// playerNotice is a message waiting to be sent to a player once stateLock is
+// released
+type playerNotice struct {
+	phoneNumber string
+	category    string
+	message     string
+}
+
+// LoadSeasons loads the calendar of seasons
+func (gm *GameManager) LoadSeasons(seasons []*types.Season) {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	for _, season := range seasons {
+		gm.seasons[season.ID] = season
+	}
+}
+
+// easter returns Easter Sunday of a year, by the anonymous Gregorian algorithm
+func easter(year int, loc *time.Location) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, loc)
+}
+
+// parseSeasonDate reads a season date in a year: "MM-DD" falls in that year,
+// "YYYY-MM-DD" in its own. It reports whether the date recurs every year.
+func parseSeasonDate(value string, year int, loc *time.Location) (time.Time, bool, error) {
+	if len(value) == len("01-02") {
+		date, err := time.ParseInLocation("2006-01-02", fmt.Sprintf("%04d-%s", year, value), loc)
+		return date, true, err
+	}
+	date, err := time.ParseInLocation("2006-01-02", value, loc)
+	return date, false, err
+}
+
+// seasonWindow returns the window of a season that a time falls in, from the
+// start of its first day to the end of its last one
+func seasonWindow(season *types.Season, now time.Time) (time.Time, time.Time, bool) {
+	for year := now.Year() - 1; year <= now.Year()+1; year++ {
+		var start, end time.Time
+		recurring := true
+
+		if season.Anchor == anchorEaster {
+			day := easter(year, now.Location())
+			start = day.AddDate(0, 0, season.StartOffset)
+			end = day.AddDate(0, 0, season.EndOffset+1)
+		} else {
+			var err error
+			if start, recurring, err = parseSeasonDate(season.Start, year, now.Location()); err != nil {
+				return time.Time{}, time.Time{}, false
+			}
+			if end, _, err = parseSeasonDate(season.End, year, now.Location()); err != nil {
+				return time.Time{}, time.Time{}, false
+			}
+			end = end.AddDate(0, 0, 1)
+
+			// Yearly windows like Réveillon run into the next year
+			if recurring && !end.After(start) {
+				end = end.AddDate(1, 0, 0)
+			}
+		}
+
+		if !now.Before(start) && now.Before(end) {
+			return start, end, true
+		}
+		if !recurring {
+			break
+		}
+	}
+
+	return time.Time{}, time.Time{}, false
+}
+
+// seasonCovers reports whether a season's reward bonus reaches a subzone; a
+// season without zones or subzones covers the whole city
+func seasonCovers(season *types.Season, zoneID, subZoneID string) bool {
+	if len(season.Zones) == 0 && len(season.SubZones) == 0 {
+		return true
+	}
+	return containsString(season.Zones, zoneID) || containsString(season.SubZones, subZoneID)
+}
+
+// seasonBonus returns the reward multiplier the seasons going on add to a
+// subzone. Callers must hold stateLock.
+func (gm *GameManager) seasonBonus(zoneID, subZoneID string) int {
+	bonus := 0
+	for _, active := range gm.activeSeasons {
+		if seasonCovers(active.season, zoneID, subZoneID) {
+			bonus += active.season.RewardMultiplier
+		}
+	}
+	return bonus
+}
+
+// activeSeasonNames returns the names of the seasons going on, sorted.
+// Callers must hold stateLock.
+func (gm *GameManager) activeSeasonNames() []string {
+	names := make([]string, 0, len(gm.activeSeasons))
+	for _, active := range gm.activeSeasons {
+		names = append(names, active.season.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// weightedEvent is an event in a weighted draw
+type weightedEvent struct {
+	event  *types.Event
+	weight int
+}
+
+// seasonalEvents returns the events of the seasons going on that can happen
+// to the player, each weighing its season's event_weight (or the configured
+// season_event_weight). Callers must hold stateLock.
+func (gm *GameManager) seasonalEvents(player *types.Player, now time.Time) []weightedEvent {
+	var events []weightedEvent
+	for _, active := range gm.activeSeasons {
+		weight := active.season.EventWeight
+		if weight <= 0 {
+			weight = gm.config.Game.SeasonEventWeight
+		}
+		if weight < 1 {
+			weight = 1
+		}
+
+		for _, id := range active.season.Events {
+			event, exists := gm.state.Events[id]
+			if !exists || !gm.eventEligible(player, event, now) {
+				continue
+			}
+			events = append(events, weightedEvent{event: event, weight: weight})
+		}
+	}
+	return events
+}
+
+// drawEvent picks an event at random, the regular ones weighing one each
+func drawEvent(regular []*types.Event, seasonal []weightedEvent) *types.Event {
+	total := len(regular)
+	for _, w := range seasonal {
+		total += w.weight
+	}
+
+	roll := rand.Intn(total)
+	if roll < len(regular) {
+		return regular[roll]
+	}
+
+	roll -= len(regular)
+	for _, w := range seasonal {
+		if roll < w.weight {
+			return w.event
+		}
+		roll -= w.weight
+	}
+	return seasonal[len(seasonal)-1].event
+}
+
+// updateSeasons opens the seasons whose window has started and closes the
+// ones that are over, and returns the messages for the players: the
+// announcement of each season they haven't heard of yet, and where players
+// in a closed temporary subzone ended up.
+func (gm *GameManager) updateSeasons(now time.Time) []playerNotice {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	// Before the first update nothing is open, so clean up after every
+	// season that isn't on, in case one ended while the server was down
+	firstUpdate := gm.activeSeasons == nil
+
+	active := make(map[string]activeSeason)
+	for _, season := range gm.seasons {
+		if start, end, ok := seasonWindow(season, now); ok {
+			active[season.ID] = activeSeason{season: season, start: start, end: end}
+		}
+	}
+
+	var notices []playerNotice
+	for id, season := range gm.seasons {
+		_, isActive := active[id]
+		_, wasActive := gm.activeSeasons[id]
+
+		switch {
+		case isActive && !wasActive:
+			gm.openSeason(season)
+		case !isActive && (wasActive || firstUpdate):
+			notices = append(notices, gm.closeSeason(season)...)
+		}
+	}
+	gm.activeSeasons = active
+
+	// Announce every season a player hasn't heard of, keeping only the
+	// seasons still on in their record
+	keys := make([]string, 0, len(active))
+	for _, a := range active {
+		keys = append(keys, a.key())
+	}
+	sort.Strings(keys)
+
+	for _, player := range gm.state.Players {
+		if player.CurrentCharacter == nil {
+			continue
+		}
+
+		for _, a := range active {
+			if containsString(player.Seasons, a.key()) {
+				continue
+			}
+			notices = append(notices, playerNotice{
+				phoneNumber: player.PhoneNumber,
+				category:    categoryNews,
+				message: fmt.Sprintf("📅 *%s CHEGOU!* 📅\n\n%s",
+					strings.ToUpper(a.season.Name), a.season.Announcement),
+			})
+		}
+
+		if strings.Join(player.Seasons, ",") != strings.Join(keys, ",") {
+			player.Seasons = append([]string(nil), keys...)
+
+			// Queue the player for the next save
+			gm.markDirty(player)
+		}
+	}
+
+	return notices
+}
+
+// openSeason adds the temporary subzones of a season to their zones. Callers
+// must hold stateLock.
+func (gm *GameManager) openSeason(season *types.Season) {
+	for _, temporary := range season.TemporarySubZones {
+		zone, exists := gm.state.Zones[temporary.Zone]
+		if !exists {
+			gm.Logger.Warn("Zone of seasonal subzone not found",
+				zap.String("season_id", season.ID),
+				zap.String("zone", temporary.Zone))
+			continue
+		}
+
+		if subZoneIndex(zone, temporary.SubZone.ID) < 0 {
+			zone.SubZones = append(zone.SubZones, temporary.SubZone)
+		}
+	}
+
+	gm.Logger.Info("Season started", zap.String("season_id", season.ID))
+}
+
+// closeSeason removes the temporary subzones of a season and sends the
+// players still there, or on the way there, to the first subzone left in
+// the zone. Callers must hold stateLock.
+func (gm *GameManager) closeSeason(season *types.Season) []playerNotice {
+	var notices []playerNotice
+	for _, temporary := range season.TemporarySubZones {
+		zone, exists := gm.state.Zones[temporary.Zone]
+		if !exists {
+			continue
+		}
+
+		if i := subZoneIndex(zone, temporary.SubZone.ID); i >= 0 {
+			zone.SubZones = append(zone.SubZones[:i:i], zone.SubZones[i+1:]...)
+		}
+		if len(zone.SubZones) == 0 {
+			continue
+		}
+		fallback := zone.SubZones[0]
+
+		for _, player := range gm.state.Players {
+			moved := false
+
+			if player.CurrentZone == zone.ID && player.CurrentSubZone == temporary.SubZone.ID {
+				player.CurrentSubZone = fallback.ID
+				moved = true
+			}
+			if player.Travel != nil && player.Travel.ToZone == zone.ID && player.Travel.ToSubZone == temporary.SubZone.ID {
+				player.Travel.ToSubZone = fallback.ID
+				moved = true
+			}
+			if player.TravelQuote != nil && player.TravelQuote.ToZone == zone.ID && player.TravelQuote.ToSubZone == temporary.SubZone.ID {
+				player.TravelQuote = nil
+				gm.markDirty(player)
+			}
+
+			if !moved {
+				continue
+			}
+
+			notices = append(notices, playerNotice{
+				phoneNumber: player.PhoneNumber,
+				category:    categoryGeneral,
+				message: fmt.Sprintf("🎭 %s acabou e *%s* fechou. Você foi parar em *%s*.",
+					season.Name, temporary.SubZone.Name, fallback.Name),
+			})
+
+			// Queue the player for the next save
+			gm.markDirty(player)
+		}
+	}
+
+	gm.Logger.Info("Season closed", zap.String("season_id", season.ID))
+	return notices
+}
+
+// subZoneIndex returns the position of a subzone in its zone, or -1
+func subZoneIndex(zone *types.Zone, subZoneID string) int {
+	for i, subZone := range zone.SubZones {
+		if subZone.ID == subZoneID {
+			return i
+		}
+	}
+	return -1
+}
+
+// CalendarSystem opens and closes the seasons of the calendar
+type CalendarSystem struct {
+	gameManager *GameManager
+	ticker      *time.Ticker
+	stopChan    chan struct{}
+	doneChan    chan struct{}
+	logger      *zap.Logger
+}
+
+// NewCalendarSystem creates a new calendar system
+func NewCalendarSystem(gameManager *GameManager, checkInterval time.Duration, logger *zap.Logger) *CalendarSystem {
+	return &CalendarSystem{
+		gameManager: gameManager,
+		ticker:      time.NewTicker(checkInterval),
+		stopChan:    make(chan struct{}),
+		doneChan:    make(chan struct{}),
+		logger:      logger,
+	}
+}
+
+// Start begins the calendar system. It checks the calendar right away, so
+// the temporary subzones of a season going on are back after a restart.
+func (cs *CalendarSystem) Start() {
+	go func() {
+		defer close(cs.doneChan)
+
+		cs.checkCalendar()
+
+		for {
+			select {
+			case <-cs.ticker.C:
+				cs.checkCalendar()
+			case <-cs.stopChan:
+				cs.ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the calendar system, waiting for a running check to finish
+func (cs *CalendarSystem) Stop() {
+	close(cs.stopChan)
+	<-cs.doneChan
+}
+
+// checkCalendar updates the seasons and messages the players about them
+func (cs *CalendarSystem) checkCalendar() {
+	for _, notice := range cs.gameManager.updateSeasons(time.Now()) {
+		if err := cs.gameManager.sendNotification(notice.phoneNumber, notice.category, notice.message); err != nil {
+			cs.logger.Error("Failed to send season message",
+				zap.String("phone_number", notice.phoneNumber),
+				zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,996 @@
+package game
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/user/vida-loka-strategy/internal/types"
+)
+
+// sqliteMigrations holds the schema changes applied in order; the index of the
+// last applied migration is tracked with PRAGMA user_version
+var sqliteMigrations = []string{
+	`CREATE TABLE IF NOT EXISTS players (
+		phone_number     TEXT PRIMARY KEY,
+		id               TEXT NOT NULL,
+		name             TEXT NOT NULL,
+		created_at       TIMESTAMP NOT NULL,
+		last_active_at   TIMESTAMP NOT NULL,
+		xp               INTEGER NOT NULL DEFAULT 0,
+		money            INTEGER NOT NULL DEFAULT 0,
+		influence        INTEGER NOT NULL DEFAULT 0,
+		status           TEXT NOT NULL DEFAULT 'active',
+		stress           INTEGER NOT NULL DEFAULT 0,
+		character_id     TEXT NOT NULL DEFAULT '',
+		current_zone     TEXT NOT NULL DEFAULT '',
+		current_sub_zone TEXT NOT NULL DEFAULT '',
+		last_event_at    TIMESTAMP
+	);
+
+	CREATE TABLE IF NOT EXISTS decisions (
+		id               TEXT PRIMARY KEY,
+		player_phone     TEXT NOT NULL REFERENCES players(phone_number) ON DELETE CASCADE,
+		event_id         TEXT NOT NULL,
+		choice           TEXT NOT NULL,
+		timestamp        TIMESTAMP NOT NULL,
+		outcome          TEXT NOT NULL,
+		xp_change        INTEGER NOT NULL DEFAULT 0,
+		money_change     INTEGER NOT NULL DEFAULT 0,
+		influence_change INTEGER NOT NULL DEFAULT 0,
+		stress_change    INTEGER NOT NULL DEFAULT 0
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_decisions_player ON decisions(player_phone);
+
+	CREATE TABLE IF NOT EXISTS pending_events (
+		player_phone TEXT PRIMARY KEY REFERENCES players(phone_number) ON DELETE CASCADE,
+		event_id     TEXT NOT NULL,
+		event_data   TEXT NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS catalog (
+		kind TEXT NOT NULL,
+		id   TEXT NOT NULL,
+		data TEXT NOT NULL,
+		PRIMARY KEY (kind, id)
+	);`,
+
+	// Trips in progress and quoted trips, as JSON
+	`ALTER TABLE players ADD COLUMN travel TEXT;
+	ALTER TABLE players ADD COLUMN travel_quote TEXT;`,
+
+	`ALTER TABLE players ADD COLUMN burnout INTEGER NOT NULL DEFAULT 0;`,
+
+	// Per-player level and attributes, as JSON
+	`ALTER TABLE players ADD COLUMN stats TEXT;`,
+
+	// Factions with their members, bank and territories, as JSON
+	`CREATE TABLE IF NOT EXISTS factions (
+		id   TEXT PRIMARY KEY,
+		data TEXT NOT NULL
+	);`,
+
+	// Heat, police presence and control of each subzone
+	`CREATE TABLE IF NOT EXISTS world (
+		zone       TEXT NOT NULL,
+		sub_zone   TEXT NOT NULL,
+		heat       INTEGER NOT NULL DEFAULT 0,
+		police     INTEGER NOT NULL DEFAULT 0,
+		control    INTEGER NOT NULL DEFAULT 0,
+		updated_at TIMESTAMP NOT NULL,
+		PRIMARY KEY (zone, sub_zone)
+	);`,
+
+	// Event arc in progress and finished arcs, as JSON
+	`ALTER TABLE players ADD COLUMN arc TEXT;
+	ALTER TABLE players ADD COLUMN completed_arcs TEXT;`,
+
+	// When the event scheduler next checks the player
+	`ALTER TABLE players ADD COLUMN next_event_at TIMESTAMP;`,
+
+	// Notification settings and the messages held during quiet hours, as JSON
+	`ALTER TABLE players ADD COLUMN notifications TEXT;`,
+
+	// Seasons going on the player was told about, as JSON
+	`ALTER TABLE players ADD COLUMN seasons TEXT;`,
+}
+
+// SQLiteStore persists game state in a SQLite database with one row per player,
+// decision and pending event, so a save only touches the players that changed
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// Ensure SQLiteStore satisfies the StateStore interface
+var _ StateStore = (*SQLiteStore)(nil)
+
+// NewSQLiteStore opens (or creates) the SQLite database at dsn and brings its
+// schema up to date
+func NewSQLiteStore(driver, dsn string) (*SQLiteStore, error) {
+	// Plain file paths get foreign keys and a busy timeout, like the WhatsApp stores
+	if !strings.HasPrefix(dsn, "file:") {
+		dsn = "file:" + dsn + "?_foreign_keys=on&_busy_timeout=5000"
+	}
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// SQLite only allows one writer at a time
+	db.SetMaxOpenConns(1)
+
+	store := &SQLiteStore{db: db}
+	if err := store.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// migrate applies any schema migrations the database hasn't seen yet
+func (s *SQLiteStore) migrate() error {
+	var version int
+	if err := s.db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	for i := version; i < len(sqliteMigrations); i++ {
+		tx, err := s.db.Begin()
+		if err != nil {
+			return fmt.Errorf("failed to begin migration %d: %w", i+1, err)
+		}
+
+		if _, err := tx.Exec(sqliteMigrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to apply migration %d: %w", i+1, err)
+		}
+
+		// PRAGMA doesn't accept bound parameters
+		if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", i+1)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to update schema version: %w", err)
+		}
+
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit migration %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// LoadPlayers returns all persisted players keyed by phone number
+func (s *SQLiteStore) LoadPlayers() (map[string]*types.Player, error) {
+	// Characters are stored by ID; resolve them against the saved catalog
+	characters, err := s.loadCatalogKind("character", func() interface{} { return &types.Character{} })
+	if err != nil {
+		return nil, err
+	}
+
+	// Evolved players point at the character of their evolution
+	evolutions, err := s.loadCatalogKind("evolution", func() interface{} { return &types.Evolution{} })
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(`SELECT phone_number, id, name, created_at, last_active_at, xp, money,
+		influence, status, stress, character_id, current_zone, current_sub_zone, last_event_at,
+		travel, travel_quote, burnout, stats, arc, completed_arcs, next_event_at,
+		notifications, seasons
+		FROM players`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query players: %w", err)
+	}
+	defer rows.Close()
+
+	players := make(map[string]*types.Player)
+	for rows.Next() {
+		var player types.Player
+		var characterID string
+		var lastEventAt, nextEventAt sql.NullTime
+		var travel, travelQuote, stats, arc, completedArcs, notifications, seasons sql.NullString
+
+		if err := rows.Scan(&player.PhoneNumber, &player.ID, &player.Name, &player.CreatedAt,
+			&player.LastActiveAt, &player.XP, &player.Money, &player.Influence, &player.Status,
+			&player.Stress, &characterID, &player.CurrentZone, &player.CurrentSubZone, &lastEventAt,
+			&travel, &travelQuote, &player.Burnout, &stats, &arc, &completedArcs, &nextEventAt,
+			&notifications, &seasons); err != nil {
+			return nil, fmt.Errorf("failed to scan player: %w", err)
+		}
+
+		if lastEventAt.Valid {
+			player.LastEventAt = lastEventAt.Time
+		}
+		if nextEventAt.Valid {
+			player.NextEventAt = nextEventAt.Time
+		}
+
+		if player.Travel, err = parseTravel(travel); err != nil {
+			return nil, fmt.Errorf("failed to parse travel for %s: %w", player.PhoneNumber, err)
+		}
+		if player.TravelQuote, err = parseTravel(travelQuote); err != nil {
+			return nil, fmt.Errorf("failed to parse travel quote for %s: %w", player.PhoneNumber, err)
+		}
+
+		if player.Stats, err = parseStats(stats); err != nil {
+			return nil, fmt.Errorf("failed to parse stats for %s: %w", player.PhoneNumber, err)
+		}
+
+		if player.Arc, err = parseArc(arc); err != nil {
+			return nil, fmt.Errorf("failed to parse arc for %s: %w", player.PhoneNumber, err)
+		}
+		if player.CompletedArcs, err = parseCompletedArcs(completedArcs); err != nil {
+			return nil, fmt.Errorf("failed to parse completed arcs for %s: %w", player.PhoneNumber, err)
+		}
+
+		if player.Notifications, err = parseNotifications(notifications); err != nil {
+			return nil, fmt.Errorf("failed to parse notifications for %s: %w", player.PhoneNumber, err)
+		}
+
+		if player.Seasons, err = parseSeasons(seasons); err != nil {
+			return nil, fmt.Errorf("failed to parse seasons for %s: %w", player.PhoneNumber, err)
+		}
+
+		if characterID != "" {
+			if character, exists := characters[characterID]; exists {
+				player.CurrentCharacter = character.(*types.Character)
+			} else if evolution, exists := evolutions[characterID]; exists {
+				player.CurrentCharacter = &evolution.(*types.Evolution).Character
+			} else {
+				player.CurrentCharacter = &types.Character{ID: characterID}
+			}
+		}
+
+		player.DecisionHistory = make([]types.Decision, 0)
+		players[player.PhoneNumber] = &player
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read players: %w", err)
+	}
+
+	if err := s.loadDecisions(players); err != nil {
+		return nil, err
+	}
+
+	if err := s.loadPendingEvents(players); err != nil {
+		return nil, err
+	}
+
+	return players, nil
+}
+
+// loadDecisions fills in the decision history of the given players
+func (s *SQLiteStore) loadDecisions(players map[string]*types.Player) error {
+	rows, err := s.db.Query(`SELECT player_phone, id, event_id, choice, timestamp, outcome,
+		xp_change, money_change, influence_change, stress_change
+		FROM decisions ORDER BY rowid`)
+	if err != nil {
+		return fmt.Errorf("failed to query decisions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var phoneNumber string
+		var decision types.Decision
+
+		if err := rows.Scan(&phoneNumber, &decision.ID, &decision.EventID, &decision.Choice,
+			&decision.Timestamp, &decision.Outcome, &decision.XPChange, &decision.MoneyChange,
+			&decision.InfluenceChange, &decision.StressChange); err != nil {
+			return fmt.Errorf("failed to scan decision: %w", err)
+		}
+
+		if player, exists := players[phoneNumber]; exists {
+			player.DecisionHistory = append(player.DecisionHistory, decision)
+		}
+	}
+
+	return rows.Err()
+}
+
+// loadPendingEvents restores the unanswered event of the given players
+func (s *SQLiteStore) loadPendingEvents(players map[string]*types.Player) error {
+	rows, err := s.db.Query(`SELECT player_phone, event_data FROM pending_events`)
+	if err != nil {
+		return fmt.Errorf("failed to query pending events: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var phoneNumber, data string
+		if err := rows.Scan(&phoneNumber, &data); err != nil {
+			return fmt.Errorf("failed to scan pending event: %w", err)
+		}
+
+		player, exists := players[phoneNumber]
+		if !exists {
+			continue
+		}
+
+		var event types.Event
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			return fmt.Errorf("failed to parse pending event for %s: %w", phoneNumber, err)
+		}
+		player.CurrentEvent = &event
+	}
+
+	return rows.Err()
+}
+
+// SavePlayers persists the given players in a single transaction
+func (s *SQLiteStore) SavePlayers(players ...*types.Player) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	for _, player := range players {
+		if err := savePlayerTx(tx, player); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit players: %w", err)
+	}
+
+	return nil
+}
+
+// savePlayerTx upserts a player row and its pending event
+func savePlayerTx(tx *sql.Tx, player *types.Player) error {
+	characterID := ""
+	if player.CurrentCharacter != nil {
+		characterID = player.CurrentCharacter.ID
+	}
+
+	travel, err := formatTravel(player.Travel)
+	if err != nil {
+		return fmt.Errorf("failed to marshal travel for %s: %w", player.PhoneNumber, err)
+	}
+
+	travelQuote, err := formatTravel(player.TravelQuote)
+	if err != nil {
+		return fmt.Errorf("failed to marshal travel quote for %s: %w", player.PhoneNumber, err)
+	}
+
+	stats, err := formatStats(player.Stats)
+	if err != nil {
+		return fmt.Errorf("failed to marshal stats for %s: %w", player.PhoneNumber, err)
+	}
+
+	arc, completedArcs, err := formatArcs(player)
+	if err != nil {
+		return fmt.Errorf("failed to marshal arcs for %s: %w", player.PhoneNumber, err)
+	}
+
+	notifications, err := formatNotifications(player.Notifications)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notifications for %s: %w", player.PhoneNumber, err)
+	}
+
+	seasons, err := formatSeasons(player.Seasons)
+	if err != nil {
+		return fmt.Errorf("failed to marshal seasons for %s: %w", player.PhoneNumber, err)
+	}
+
+	_, err = tx.Exec(`INSERT INTO players (phone_number, id, name, created_at, last_active_at, xp,
+		money, influence, status, stress, character_id, current_zone, current_sub_zone, last_event_at,
+		travel, travel_quote, burnout, stats, arc, completed_arcs, next_event_at, notifications,
+		seasons)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(phone_number) DO UPDATE SET
+			id = excluded.id,
+			name = excluded.name,
+			last_active_at = excluded.last_active_at,
+			xp = excluded.xp,
+			money = excluded.money,
+			influence = excluded.influence,
+			status = excluded.status,
+			stress = excluded.stress,
+			character_id = excluded.character_id,
+			current_zone = excluded.current_zone,
+			current_sub_zone = excluded.current_sub_zone,
+			last_event_at = excluded.last_event_at,
+			travel = excluded.travel,
+			travel_quote = excluded.travel_quote,
+			burnout = excluded.burnout,
+			stats = excluded.stats,
+			arc = excluded.arc,
+			completed_arcs = excluded.completed_arcs,
+			next_event_at = excluded.next_event_at,
+			notifications = excluded.notifications,
+			seasons = excluded.seasons`,
+		player.PhoneNumber, player.ID, player.Name, player.CreatedAt, player.LastActiveAt, player.XP,
+		player.Money, player.Influence, player.Status, player.Stress, characterID,
+		player.CurrentZone, player.CurrentSubZone, player.LastEventAt, travel, travelQuote,
+		player.Burnout, stats, arc, completedArcs, player.NextEventAt,
+		notifications, seasons)
+	if err != nil {
+		return fmt.Errorf("failed to save player %s: %w", player.PhoneNumber, err)
+	}
+
+	if player.CurrentEvent == nil {
+		if _, err := tx.Exec(`DELETE FROM pending_events WHERE player_phone = ?`, player.PhoneNumber); err != nil {
+			return fmt.Errorf("failed to clear pending event for %s: %w", player.PhoneNumber, err)
+		}
+		return nil
+	}
+
+	data, err := json.Marshal(player.CurrentEvent)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pending event for %s: %w", player.PhoneNumber, err)
+	}
+
+	if _, err := tx.Exec(`INSERT OR REPLACE INTO pending_events (player_phone, event_id, event_data)
+		VALUES (?, ?, ?)`, player.PhoneNumber, player.CurrentEvent.ID, string(data)); err != nil {
+		return fmt.Errorf("failed to save pending event for %s: %w", player.PhoneNumber, err)
+	}
+
+	return nil
+}
+
+// formatTravel encodes a trip for a travel column, NULL when there is none
+func formatTravel(travel *types.Travel) (sql.NullString, error) {
+	if travel == nil {
+		return sql.NullString{}, nil
+	}
+	return marshalColumn(travel)
+}
+
+// formatStats encodes a player's stats for the stats column, NULL when there are none
+func formatStats(stats *types.Stats) (sql.NullString, error) {
+	if stats == nil {
+		return sql.NullString{}, nil
+	}
+	return marshalColumn(stats)
+}
+
+// formatArcs encodes a player's arc in progress and finished arcs for the
+// arc columns, NULL when there are none
+func formatArcs(player *types.Player) (sql.NullString, sql.NullString, error) {
+	var arc, completed sql.NullString
+	var err error
+
+	if player.Arc != nil {
+		if arc, err = marshalColumn(player.Arc); err != nil {
+			return arc, completed, err
+		}
+	}
+
+	if len(player.CompletedArcs) > 0 {
+		if completed, err = marshalColumn(player.CompletedArcs); err != nil {
+			return arc, completed, err
+		}
+	}
+
+	return arc, completed, nil
+}
+
+// marshalColumn encodes a value for a JSON column
+func marshalColumn(value interface{}) (sql.NullString, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+// formatNotifications encodes notification settings for the notifications
+// column, NULL when the player never changed them
+func formatNotifications(settings *types.NotificationSettings) (sql.NullString, error) {
+	if settings == nil {
+		return sql.NullString{}, nil
+	}
+	return marshalColumn(settings)
+}
+
+// formatSeasons encodes the seasons a player was told about for the seasons
+// column, NULL when there are none
+func formatSeasons(seasons []string) (sql.NullString, error) {
+	if len(seasons) == 0 {
+		return sql.NullString{}, nil
+	}
+	return marshalColumn(seasons)
+}
+
+// parseStats decodes a stats column written by formatStats
+func parseStats(column sql.NullString) (*types.Stats, error) {
+	if !column.Valid || column.String == "" {
+		return nil, nil
+	}
+
+	var stats types.Stats
+	if err := json.Unmarshal([]byte(column.String), &stats); err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// parseArc decodes an arc column written by formatArcs
+func parseArc(column sql.NullString) (*types.EventArc, error) {
+	if !column.Valid || column.String == "" {
+		return nil, nil
+	}
+
+	var arc types.EventArc
+	if err := json.Unmarshal([]byte(column.String), &arc); err != nil {
+		return nil, err
+	}
+
+	return &arc, nil
+}
+
+// parseCompletedArcs decodes a completed_arcs column written by formatArcs
+func parseCompletedArcs(column sql.NullString) ([]types.EventArc, error) {
+	if !column.Valid || column.String == "" {
+		return nil, nil
+	}
+
+	var arcs []types.EventArc
+	if err := json.Unmarshal([]byte(column.String), &arcs); err != nil {
+		return nil, err
+	}
+
+	return arcs, nil
+}
+
+// parseNotifications decodes a notifications column written by
+// formatNotifications
+func parseNotifications(column sql.NullString) (*types.NotificationSettings, error) {
+	if !column.Valid || column.String == "" {
+		return nil, nil
+	}
+
+	var settings types.NotificationSettings
+	if err := json.Unmarshal([]byte(column.String), &settings); err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+// parseSeasons decodes a seasons column written by formatSeasons
+func parseSeasons(column sql.NullString) ([]string, error) {
+	if !column.Valid || column.String == "" {
+		return nil, nil
+	}
+
+	var seasons []string
+	if err := json.Unmarshal([]byte(column.String), &seasons); err != nil {
+		return nil, err
+	}
+
+	return seasons, nil
+}
+
+// parseTravel decodes a travel column written by formatTravel
+func parseTravel(column sql.NullString) (*types.Travel, error) {
+	if !column.Valid || column.String == "" {
+		return nil, nil
+	}
+
+	var travel types.Travel
+	if err := json.Unmarshal([]byte(column.String), &travel); err != nil {
+		return nil, err
+	}
+
+	return &travel, nil
+}
+
+// SaveBatch upserts the players of a batch and then inserts their new
+// decisions, so decisions always reference a saved player, and saves the
+// factions and subzones of the batch, all in one transaction
+func (s *SQLiteStore) SaveBatch(batch Batch) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	for _, player := range batch.Players {
+		if err := savePlayerTx(tx, player); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	for _, pending := range batch.Decisions {
+		if err := insertDecisionTx(tx, pending.PhoneNumber, pending.Decision); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	for _, faction := range batch.Factions {
+		if err := saveFactionTx(tx, faction); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	for _, id := range batch.DeletedFactions {
+		if _, err := tx.Exec(`DELETE FROM factions WHERE id = ?`, id); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to delete faction %s: %w", id, err)
+		}
+	}
+
+	for _, state := range batch.World {
+		if err := saveSubZoneStateTx(tx, state); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit batch: %w", err)
+	}
+
+	return nil
+}
+
+// insertDecisionTx inserts a single decision row
+func insertDecisionTx(tx *sql.Tx, phoneNumber string, decision types.Decision) error {
+	_, err := tx.Exec(`INSERT OR IGNORE INTO decisions (id, player_phone, event_id, choice, timestamp,
+		outcome, xp_change, money_change, influence_change, stress_change)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		decision.ID, phoneNumber, decision.EventID, decision.Choice, decision.Timestamp,
+		decision.Outcome, decision.XPChange, decision.MoneyChange, decision.InfluenceChange,
+		decision.StressChange)
+	if err != nil {
+		return fmt.Errorf("failed to save decision for %s: %w", phoneNumber, err)
+	}
+
+	return nil
+}
+
+// LoadCatalog returns the persisted characters, events, actions, zones and evolutions
+func (s *SQLiteStore) LoadCatalog() (*types.GameState, error) {
+	state := newEmptyGameState()
+
+	characters, err := s.loadCatalogKind("character", func() interface{} { return &types.Character{} })
+	if err != nil {
+		return nil, err
+	}
+	for id, character := range characters {
+		state.Characters[id] = character.(*types.Character)
+	}
+
+	events, err := s.loadCatalogKind("event", func() interface{} { return &types.Event{} })
+	if err != nil {
+		return nil, err
+	}
+	for id, event := range events {
+		state.Events[id] = event.(*types.Event)
+	}
+
+	actions, err := s.loadCatalogKind("action", func() interface{} { return &types.Action{} })
+	if err != nil {
+		return nil, err
+	}
+	for id, action := range actions {
+		state.Actions[id] = action.(*types.Action)
+	}
+
+	zones, err := s.loadCatalogKind("zone", func() interface{} { return &types.Zone{} })
+	if err != nil {
+		return nil, err
+	}
+	for id, zone := range zones {
+		state.Zones[id] = zone.(*types.Zone)
+	}
+
+	evolutions, err := s.loadCatalogKind("evolution", func() interface{} { return &types.Evolution{} })
+	if err != nil {
+		return nil, err
+	}
+	for id, evolution := range evolutions {
+		state.Evolutions[id] = evolution.(*types.Evolution)
+	}
+
+	return state, nil
+}
+
+// loadCatalogKind decodes every catalog entry of one kind into values built by newValue
+func (s *SQLiteStore) loadCatalogKind(kind string, newValue func() interface{}) (map[string]interface{}, error) {
+	rows, err := s.db.Query(`SELECT id, data FROM catalog WHERE kind = ?`, kind)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s catalog: %w", kind, err)
+	}
+	defer rows.Close()
+
+	entries := make(map[string]interface{})
+	for rows.Next() {
+		var id, data string
+		if err := rows.Scan(&id, &data); err != nil {
+			return nil, fmt.Errorf("failed to scan %s catalog: %w", kind, err)
+		}
+
+		value := newValue()
+		if err := json.Unmarshal([]byte(data), value); err != nil {
+			return nil, fmt.Errorf("failed to parse %s %s: %w", kind, id, err)
+		}
+		entries[id] = value
+	}
+
+	return entries, rows.Err()
+}
+
+// SaveCatalog replaces the persisted characters, events, actions, zones and evolutions
+func (s *SQLiteStore) SaveCatalog(state *types.GameState) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := saveCatalogTx(tx, state); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit catalog: %w", err)
+	}
+
+	return nil
+}
+
+// saveCatalogTx rewrites the catalog table from the given state
+func saveCatalogTx(tx *sql.Tx, state *types.GameState) error {
+	if _, err := tx.Exec(`DELETE FROM catalog`); err != nil {
+		return fmt.Errorf("failed to clear catalog: %w", err)
+	}
+
+	entries := make(map[string]map[string]interface{})
+	entries["character"] = make(map[string]interface{})
+	for id, character := range state.Characters {
+		entries["character"][id] = character
+	}
+	entries["event"] = make(map[string]interface{})
+	for id, event := range state.Events {
+		entries["event"][id] = event
+	}
+	entries["action"] = make(map[string]interface{})
+	for id, action := range state.Actions {
+		entries["action"][id] = action
+	}
+	entries["zone"] = make(map[string]interface{})
+	for id, zone := range state.Zones {
+		entries["zone"][id] = zone
+	}
+	entries["evolution"] = make(map[string]interface{})
+	for id, evolution := range state.Evolutions {
+		entries["evolution"][id] = evolution
+	}
+
+	for kind, values := range entries {
+		for id, value := range values {
+			data, err := json.Marshal(value)
+			if err != nil {
+				return fmt.Errorf("failed to marshal %s %s: %w", kind, id, err)
+			}
+
+			if _, err := tx.Exec(`INSERT INTO catalog (kind, id, data) VALUES (?, ?, ?)`,
+				kind, id, string(data)); err != nil {
+				return fmt.Errorf("failed to save %s %s: %w", kind, id, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadFactions returns all persisted factions keyed by ID
+func (s *SQLiteStore) LoadFactions() (map[string]*types.Faction, error) {
+	rows, err := s.db.Query(`SELECT id, data FROM factions`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query factions: %w", err)
+	}
+	defer rows.Close()
+
+	factions := make(map[string]*types.Faction)
+	for rows.Next() {
+		var id, data string
+		if err := rows.Scan(&id, &data); err != nil {
+			return nil, fmt.Errorf("failed to scan faction: %w", err)
+		}
+
+		var faction types.Faction
+		if err := json.Unmarshal([]byte(data), &faction); err != nil {
+			return nil, fmt.Errorf("failed to parse faction %s: %w", id, err)
+		}
+		factions[id] = &faction
+	}
+
+	return factions, rows.Err()
+}
+
+// SaveFactions upserts the given factions in a single transaction
+func (s *SQLiteStore) SaveFactions(factions ...*types.Faction) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	for _, faction := range factions {
+		if err := saveFactionTx(tx, faction); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit factions: %w", err)
+	}
+
+	return nil
+}
+
+// saveFactionTx upserts a faction
+func saveFactionTx(tx *sql.Tx, faction *types.Faction) error {
+	data, err := json.Marshal(faction)
+	if err != nil {
+		return fmt.Errorf("failed to marshal faction %s: %w", faction.ID, err)
+	}
+
+	if _, err := tx.Exec(`INSERT INTO factions (id, data) VALUES (?, ?)
+		ON CONFLICT(id) DO UPDATE SET data = excluded.data`, faction.ID, string(data)); err != nil {
+		return fmt.Errorf("failed to save faction %s: %w", faction.ID, err)
+	}
+
+	return nil
+}
+
+// DeleteFaction removes a disbanded faction
+func (s *SQLiteStore) DeleteFaction(id string) error {
+	if _, err := s.db.Exec(`DELETE FROM factions WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete faction %s: %w", id, err)
+	}
+	return nil
+}
+
+// LoadWorld returns the persisted dynamic state of the subzones keyed by
+// "zone/subzone"
+func (s *SQLiteStore) LoadWorld() (map[string]*types.SubZoneState, error) {
+	rows, err := s.db.Query(`SELECT zone, sub_zone, heat, police, control, updated_at FROM world`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query world: %w", err)
+	}
+	defer rows.Close()
+
+	world := make(map[string]*types.SubZoneState)
+	for rows.Next() {
+		var state types.SubZoneState
+		if err := rows.Scan(&state.Zone, &state.SubZone, &state.Heat, &state.Police, &state.Control, &state.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan subzone state: %w", err)
+		}
+		world[subZoneKey(state.Zone, state.SubZone)] = &state
+	}
+
+	return world, rows.Err()
+}
+
+// SaveWorld upserts the given subzone states in a single transaction
+func (s *SQLiteStore) SaveWorld(states ...*types.SubZoneState) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	for _, state := range states {
+		if err := saveSubZoneStateTx(tx, state); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit world: %w", err)
+	}
+
+	return nil
+}
+
+// saveSubZoneStateTx upserts the state of a subzone
+func saveSubZoneStateTx(tx *sql.Tx, state *types.SubZoneState) error {
+	if _, err := tx.Exec(`INSERT INTO world (zone, sub_zone, heat, police, control, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(zone, sub_zone) DO UPDATE SET
+			heat = excluded.heat,
+			police = excluded.police,
+			control = excluded.control,
+			updated_at = excluded.updated_at`,
+		state.Zone, state.SubZone, state.Heat, state.Police, state.Control, state.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to save subzone state %s: %w", subZoneKey(state.Zone, state.SubZone), err)
+	}
+
+	return nil
+}
+
+// MigrateFromJSON imports a legacy game_state.json file into an empty database.
+// The file is renamed with a .migrated suffix afterwards so the import only
+// ever runs once. It returns the number of players imported.
+func (s *SQLiteStore) MigrateFromJSON(path string) (int, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return 0, nil
+	}
+
+	var count int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM players`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count players: %w", err)
+	}
+	if count > 0 {
+		return 0, nil
+	}
+
+	state, err := NewGameStateStorage(path, 0, 0).LoadGameState()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load legacy game state: %w", err)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	for phoneNumber, player := range state.Players {
+		// Older saves didn't always keep the phone number on the player itself
+		if player.PhoneNumber == "" {
+			player.PhoneNumber = phoneNumber
+		}
+
+		if err := savePlayerTx(tx, player); err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+
+		for _, decision := range player.DecisionHistory {
+			if err := insertDecisionTx(tx, player.PhoneNumber, decision); err != nil {
+				tx.Rollback()
+				return 0, err
+			}
+		}
+	}
+
+	if err := saveCatalogTx(tx, state); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	for _, faction := range state.Factions {
+		if err := saveFactionTx(tx, faction); err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	}
+
+	for _, subZoneState := range state.World {
+		if err := saveSubZoneStateTx(tx, subZoneState); err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit migration: %w", err)
+	}
+
+	migratedPath := fmt.Sprintf("%s.migrated-%s", path, time.Now().Format("20060102150405"))
+	if err := os.Rename(path, migratedPath); err != nil {
+		return len(state.Players), fmt.Errorf("failed to rename migrated game state: %w", err)
+	}
+
+	return len(state.Players), nil
+}
+
+// Close closes the underlying database
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
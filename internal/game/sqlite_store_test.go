@@ -0,0 +1,212 @@
+package game
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/user/vida-loka-strategy/internal/types"
+)
+
+// openTestSQLiteStore opens a store at path, closed when the test ends
+func openTestSQLiteStore(t *testing.T, path string) *SQLiteStore {
+	t.Helper()
+
+	store, err := NewSQLiteStore("sqlite3", path)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestSQLiteStoreMigratesFromVersionZero(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vida-loka.db")
+	store := openTestSQLiteStore(t, path)
+
+	var version int
+	if err := store.db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		t.Fatalf("failed to read schema version: %v", err)
+	}
+	if version != len(sqliteMigrations) {
+		t.Fatalf("schema version = %d, want %d", version, len(sqliteMigrations))
+	}
+
+	// Every column added by the migrations round-trips through a save
+	now := time.Now().Truncate(time.Second)
+	player := &types.Player{
+		ID:           "p1",
+		PhoneNumber:  "5521999990000",
+		Name:         "Ana",
+		CreatedAt:    now,
+		LastActiveAt: now,
+		Status:       "active",
+		Stress:       40,
+		LastEventAt:  now,
+		NextEventAt:  now.Add(time.Hour),
+		Travel:       &types.Travel{FromZone: "centro", ToZone: "zona_sul", Cost: 10, ArrivesAt: now},
+		Burnout:      true,
+		Stats:        &types.Stats{Level: 2, Carisma: 5},
+		Arc:          &types.EventArc{StartEventID: "evento_001", StartedAt: now},
+		Notifications: &types.NotificationSettings{
+			QuietHours: &types.QuietHours{Start: 22, End: 7},
+			Frequency:  frequencyLow,
+			Muted:      []string{categoryPvP},
+		},
+		Seasons: []string{"carnaval:2026-02-13"},
+	}
+	decision := types.Decision{ID: "d1", EventID: "action_trabalhar", Choice: "trabalhar", Timestamp: now, Outcome: "Trabalhar"}
+
+	if err := store.SaveBatch(Batch{
+		Players:   []*types.Player{player},
+		Decisions: []PlayerDecision{{PhoneNumber: player.PhoneNumber, Decision: decision}},
+	}); err != nil {
+		t.Fatalf("failed to save batch: %v", err)
+	}
+
+	// Reopening an up-to-date database applies nothing
+	store.Close()
+	store = openTestSQLiteStore(t, path)
+
+	players, err := store.LoadPlayers()
+	if err != nil {
+		t.Fatalf("failed to load players: %v", err)
+	}
+
+	loaded, exists := players[player.PhoneNumber]
+	if !exists {
+		t.Fatalf("player %s not loaded", player.PhoneNumber)
+	}
+	if !loaded.NextEventAt.Equal(player.NextEventAt) {
+		t.Errorf("next event at = %v, want %v", loaded.NextEventAt, player.NextEventAt)
+	}
+	if loaded.Travel == nil || loaded.Travel.ToZone != "zona_sul" {
+		t.Errorf("travel = %+v, want a trip to zona_sul", loaded.Travel)
+	}
+	if !loaded.Burnout || loaded.Stats == nil || loaded.Stats.Carisma != 5 {
+		t.Errorf("burnout = %v, stats = %+v", loaded.Burnout, loaded.Stats)
+	}
+	if loaded.Arc == nil || loaded.Arc.StartEventID != "evento_001" {
+		t.Errorf("arc = %+v, want evento_001", loaded.Arc)
+	}
+	if loaded.Notifications == nil || loaded.Notifications.QuietHours == nil || loaded.Notifications.QuietHours.Start != 22 {
+		t.Errorf("notifications = %+v, want quiet hours from 22", loaded.Notifications)
+	}
+	if len(loaded.Seasons) != 1 {
+		t.Errorf("seasons = %v, want 1", loaded.Seasons)
+	}
+	if len(loaded.DecisionHistory) != 1 || loaded.DecisionHistory[0].ID != "d1" {
+		t.Errorf("decision history = %+v, want d1", loaded.DecisionHistory)
+	}
+}
+
+// writeLegacyState writes a game_state.json with one player, and returns its path
+func writeLegacyState(t *testing.T, dir, phoneNumber string) string {
+	t.Helper()
+
+	now := time.Now().Truncate(time.Second)
+	state := newEmptyGameState()
+	state.Characters["malandro"] = &types.Character{ID: "malandro", Name: "Malandro", Carisma: 8}
+	state.Players[phoneNumber] = &types.Player{
+		ID:               "legacy",
+		Name:             "Beto",
+		CreatedAt:        now,
+		LastActiveAt:     now,
+		Status:           "active",
+		Money:            250,
+		CurrentCharacter: state.Characters["malandro"],
+		CurrentZone:      "centro",
+		CurrentSubZone:   "lapa",
+		DecisionHistory: []types.Decision{
+			{ID: "legacy-d1", EventID: "evento_001", Choice: "opt_001_a", Timestamp: now, Outcome: "Estudou"},
+		},
+	}
+	state.Factions["bonde"] = &types.Faction{ID: "bonde", Name: "Bonde", CreatedAt: now}
+	state.World[subZoneKey("centro", "lapa")] = &types.SubZoneState{Zone: "centro", SubZone: "lapa", Heat: 30, UpdatedAt: now}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatalf("failed to marshal legacy state: %v", err)
+	}
+
+	path := filepath.Join(dir, "game_state.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("failed to write legacy state: %v", err)
+	}
+	return path
+}
+
+func TestSQLiteStoreMigrateFromJSON(t *testing.T) {
+	dir := t.TempDir()
+	store := openTestSQLiteStore(t, filepath.Join(dir, "vida-loka.db"))
+
+	// Older saves keep the phone number only as the map key
+	path := writeLegacyState(t, dir, "5521988880000")
+
+	imported, err := store.MigrateFromJSON(path)
+	if err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	if imported != 1 {
+		t.Fatalf("imported = %d, want 1", imported)
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("legacy state still at %s", path)
+	}
+	if matches, _ := filepath.Glob(path + ".migrated-*"); len(matches) != 1 {
+		t.Errorf("migrated files = %v, want 1", matches)
+	}
+
+	players, err := store.LoadPlayers()
+	if err != nil {
+		t.Fatalf("failed to load players: %v", err)
+	}
+	player, exists := players["5521988880000"]
+	if !exists {
+		t.Fatalf("imported player not found in %v", players)
+	}
+	if player.Money != 250 || player.CurrentSubZone != "lapa" {
+		t.Errorf("player = %+v, want R$ 250 in lapa", player)
+	}
+	if player.CurrentCharacter == nil || player.CurrentCharacter.ID != "malandro" {
+		t.Errorf("character = %+v, want malandro", player.CurrentCharacter)
+	}
+	if len(player.DecisionHistory) != 1 {
+		t.Errorf("decision history = %+v, want 1 decision", player.DecisionHistory)
+	}
+
+	factions, err := store.LoadFactions()
+	if err != nil {
+		t.Fatalf("failed to load factions: %v", err)
+	}
+	if _, exists := factions["bonde"]; !exists {
+		t.Errorf("faction bonde not imported")
+	}
+
+	world, err := store.LoadWorld()
+	if err != nil {
+		t.Fatalf("failed to load world: %v", err)
+	}
+	if state, exists := world[subZoneKey("centro", "lapa")]; !exists || state.Heat != 30 {
+		t.Errorf("world = %+v, want lapa with heat 30", world)
+	}
+
+	// A database that already has players never imports again
+	path = writeLegacyState(t, dir, "5521977770000")
+
+	imported, err = store.MigrateFromJSON(path)
+	if err != nil {
+		t.Fatalf("failed to migrate again: %v", err)
+	}
+	if imported != 0 {
+		t.Errorf("imported = %d on a database with players, want 0", imported)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("legacy state moved although nothing was imported: %v", err)
+	}
+}
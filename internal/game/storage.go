@@ -2,32 +2,160 @@ package game
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/user/vida-loka-strategy/config"
 	"github.com/user/vida-loka-strategy/internal/types"
+	"go.uber.org/zap"
 )
 
-// GameStateStorage handles persistence of game state
+// StateStore persists players and the content catalog
+type StateStore interface {
+	// LoadPlayers returns all persisted players keyed by phone number
+	LoadPlayers() (map[string]*types.Player, error)
+
+	// SavePlayers persists the given players
+	SavePlayers(players ...*types.Player) error
+
+	// SaveBatch persists everything in a batch in one write, so a flush
+	// costs a single commit however many commands it covers
+	SaveBatch(batch Batch) error
+
+	// LoadCatalog returns the persisted characters, events, actions, zones and evolutions
+	LoadCatalog() (*types.GameState, error)
+
+	// SaveCatalog persists the characters, events, actions, zones and evolutions of a state
+	SaveCatalog(state *types.GameState) error
+
+	// LoadFactions returns all persisted factions keyed by ID
+	LoadFactions() (map[string]*types.Faction, error)
+
+	// SaveFactions persists the given factions
+	SaveFactions(factions ...*types.Faction) error
+
+	// DeleteFaction removes a disbanded faction
+	DeleteFaction(id string) error
+
+	// LoadWorld returns the persisted dynamic state of the subzones keyed by
+	// "zone/subzone"
+	LoadWorld() (map[string]*types.SubZoneState, error)
+
+	// SaveWorld persists the dynamic state of the given subzones
+	SaveWorld(states ...*types.SubZoneState) error
+
+	// Close releases any resources held by the store
+	Close() error
+}
+
+// PlayerDecision is a new decision in a player's history waiting to be saved
+type PlayerDecision struct {
+	PhoneNumber string
+	Decision    types.Decision
+}
+
+// Batch is everything a flush writes to a store: the dirty players with
+// their new decisions, the dirty factions and the ones disbanded, and the
+// dirty subzones
+type Batch struct {
+	Players         []*types.Player
+	Decisions       []PlayerDecision
+	Factions        []*types.Faction
+	DeletedFactions []string
+	World           []*types.SubZoneState
+}
+
+// legacyStatePath is where the JSON storage keeps the whole game state by default
+const legacyStatePath = "./data/game_state.json"
+
+// NewStateStore opens the store for the configured database driver:
+// "sqlite3" (importing a legacy JSON state into a fresh database), "json" for
+// a single file at DSN, or "memory" for a store that doesn't survive restarts
+func NewStateStore(cfg config.DatabaseConfig) (StateStore, error) {
+	switch cfg.Driver {
+	case "sqlite3":
+		store, err := NewSQLiteStore(cfg.Driver, cfg.DSN)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := store.MigrateFromJSON(legacyStatePath); err != nil {
+			store.Close()
+			return nil, err
+		}
+
+		return store, nil
+	case "json":
+		savePath := cfg.DSN
+		if savePath == "" {
+			savePath = legacyStatePath
+		}
+		return NewGameStateStorage(savePath, cfg.SnapshotCount,
+			time.Duration(cfg.SnapshotInterval)*time.Minute), nil
+	case "memory":
+		return NewMemoryStore(), nil
+	case "":
+		return nil, errors.New("no database driver configured")
+	default:
+		return nil, fmt.Errorf("unsupported database driver: %s", cfg.Driver)
+	}
+}
+
+// newEmptyGameState returns a game state with all maps initialized
+func newEmptyGameState() *types.GameState {
+	return &types.GameState{
+		Players:    make(map[string]*types.Player),
+		Characters: make(map[string]*types.Character),
+		Events:     make(map[string]*types.Event),
+		Actions:    make(map[string]*types.Action),
+		Zones:      make(map[string]*types.Zone),
+		Evolutions: make(map[string]*types.Evolution),
+		Factions:   make(map[string]*types.Faction),
+		World:      make(map[string]*types.SubZoneState),
+	}
+}
+
+// ErrCorruptState is returned when the saved game state can't be parsed
+var ErrCorruptState = errors.New("corrupt game state")
+
+// GameStateStorage handles persistence of game state in a single JSON file
 type GameStateStorage struct {
 	savePath  string
 	stateLock sync.RWMutex
+
+	// Timestamped copies of the save file kept for recovery
+	snapshotCount    int
+	snapshotInterval time.Duration
+	lastSnapshot     time.Time
+
+	// state caches the whole document, since every save rewrites the file
+	state     *types.GameState
+	cacheLock sync.Mutex
 }
 
-// NewGameStateStorage creates a new game state storage
-func NewGameStateStorage(savePath string) *GameStateStorage {
+// Ensure GameStateStorage satisfies the StateStore interface
+var _ StateStore = (*GameStateStorage)(nil)
+
+// NewGameStateStorage creates a new game state storage that keeps up to
+// snapshotCount snapshots, taken at most once per snapshotInterval
+func NewGameStateStorage(savePath string, snapshotCount int, snapshotInterval time.Duration) *GameStateStorage {
 	// Create data directory if it doesn't exist
 	dir := filepath.Dir(savePath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		// If we can't create the directory, we'll just use the default path
-		savePath = "./data/game_state.json"
+		savePath = legacyStatePath
 	}
 
 	return &GameStateStorage{
-		savePath: savePath,
+		savePath:         savePath,
+		snapshotCount:    snapshotCount,
+		snapshotInterval: snapshotInterval,
 	}
 }
 
@@ -48,15 +176,23 @@ func (gss *GameStateStorage) SaveGameState(state *types.GameState) error {
 		return fmt.Errorf("failed to marshal game state: %w", err)
 	}
 
-	// Write to file
-	if err := os.WriteFile(gss.savePath, data, 0644); err != nil {
+	// Replace the file atomically so a crash never leaves a half-written save
+	if err := writeFileAtomic(gss.savePath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write game state: %w", err)
 	}
 
+	if gss.snapshotCount > 0 && time.Since(gss.lastSnapshot) >= gss.snapshotInterval {
+		if err := gss.writeSnapshot(data); err != nil {
+			return fmt.Errorf("failed to write game state snapshot: %w", err)
+		}
+		gss.lastSnapshot = time.Now()
+	}
+
 	return nil
 }
 
-// LoadGameState loads the game state from disk
+// LoadGameState loads the game state from disk. A file that exists but can't
+// be parsed yields ErrCorruptState instead of an empty state.
 func (gss *GameStateStorage) LoadGameState() (*types.GameState, error) {
 	gss.stateLock.Lock()
 	defer gss.stateLock.Unlock()
@@ -64,17 +200,16 @@ func (gss *GameStateStorage) LoadGameState() (*types.GameState, error) {
 	// Check if file exists
 	if _, err := os.Stat(gss.savePath); os.IsNotExist(err) {
 		// Return empty state if file doesn't exist
-		return &types.GameState{
-			Players:    make(map[string]*types.Player),
-			Characters: make(map[string]*types.Character),
-			Events:     make(map[string]*types.Event),
-			Actions:    make(map[string]*types.Action),
-			Zones:      make(map[string]*types.Zone),
-		}, nil
+		return newEmptyGameState(), nil
 	}
 
+	return readGameStateFile(gss.savePath)
+}
+
+// readGameStateFile parses a game state file, initializing any missing maps
+func readGameStateFile(path string) (*types.GameState, error) {
 	// Read file
-	data, err := os.ReadFile(gss.savePath)
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read game state file: %w", err)
 	}
@@ -82,7 +217,7 @@ func (gss *GameStateStorage) LoadGameState() (*types.GameState, error) {
 	// Unmarshal JSON
 	var state types.GameState
 	if err := json.Unmarshal(data, &state); err != nil {
-		return nil, fmt.Errorf("failed to parse game state: %w", err)
+		return nil, fmt.Errorf("%w: %s: %v", ErrCorruptState, path, err)
 	}
 
 	// Ensure all maps are initialized
@@ -101,6 +236,15 @@ func (gss *GameStateStorage) LoadGameState() (*types.GameState, error) {
 	if state.Zones == nil {
 		state.Zones = make(map[string]*types.Zone)
 	}
+	if state.Evolutions == nil {
+		state.Evolutions = make(map[string]*types.Evolution)
+	}
+	if state.Factions == nil {
+		state.Factions = make(map[string]*types.Faction)
+	}
+	if state.World == nil {
+		state.World = make(map[string]*types.SubZoneState)
+	}
 
 	// Ensure all zones have initialized subzones
 	for _, zone := range state.Zones {
@@ -112,32 +256,480 @@ func (gss *GameStateStorage) LoadGameState() (*types.GameState, error) {
 	return &state, nil
 }
 
+// writeFileAtomic writes data to a temp file next to path, syncs it and renames
+// it over path, so readers only ever see the old or the new contents
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	// Remove the temp file on any failure below
+	success := false
+	defer func() {
+		if !success {
+			tmp.Close()
+			os.Remove(tmpPath)
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+	success = true
+
+	// Sync the directory so the rename itself survives a crash
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+
+	return nil
+}
+
+// snapshotDir is where the timestamped copies of the save file live
+func (gss *GameStateStorage) snapshotDir() string {
+	return filepath.Join(filepath.Dir(gss.savePath), "snapshots")
+}
+
+// snapshotPrefix is the file name prefix shared by all snapshots of the save file
+func (gss *GameStateStorage) snapshotPrefix() string {
+	base := filepath.Base(gss.savePath)
+	return strings.TrimSuffix(base, filepath.Ext(base)) + "-"
+}
+
+// listSnapshots returns the snapshot paths, oldest first
+func (gss *GameStateStorage) listSnapshots() ([]string, error) {
+	entries, err := os.ReadDir(gss.snapshotDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := gss.snapshotPrefix()
+	snapshots := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), prefix) || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		snapshots = append(snapshots, filepath.Join(gss.snapshotDir(), entry.Name()))
+	}
+
+	// Timestamps are zero-padded, so name order is chronological order
+	sort.Strings(snapshots)
+	return snapshots, nil
+}
+
+// writeSnapshot stores a timestamped copy of the save and prunes old ones
+func (gss *GameStateStorage) writeSnapshot(data []byte) error {
+	if err := os.MkdirAll(gss.snapshotDir(), 0755); err != nil {
+		return err
+	}
+
+	name := gss.snapshotPrefix() + time.Now().Format("20060102-150405.000") + ".json"
+	if err := writeFileAtomic(filepath.Join(gss.snapshotDir(), name), data, 0644); err != nil {
+		return err
+	}
+
+	snapshots, err := gss.listSnapshots()
+	if err != nil {
+		return err
+	}
+
+	for len(snapshots) > gss.snapshotCount {
+		if err := os.Remove(snapshots[0]); err != nil {
+			return err
+		}
+		snapshots = snapshots[1:]
+	}
+
+	return nil
+}
+
+// RestoreLatestSnapshot replaces the save file with the newest snapshot that
+// still parses. The replaced file is kept with a .corrupt suffix. It returns
+// the path of the snapshot that was restored.
+func (gss *GameStateStorage) RestoreLatestSnapshot() (string, error) {
+	gss.stateLock.Lock()
+	defer gss.stateLock.Unlock()
+
+	snapshots, err := gss.listSnapshots()
+	if err != nil {
+		return "", fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if _, err := readGameStateFile(snapshots[i]); err != nil {
+			continue
+		}
+
+		data, err := os.ReadFile(snapshots[i])
+		if err != nil {
+			return "", fmt.Errorf("failed to read snapshot: %w", err)
+		}
+
+		// Keep the broken save around for inspection
+		if _, err := os.Stat(gss.savePath); err == nil {
+			corruptPath := fmt.Sprintf("%s.corrupt-%s", gss.savePath, time.Now().Format("20060102150405"))
+			if err := os.Rename(gss.savePath, corruptPath); err != nil {
+				return "", fmt.Errorf("failed to move corrupt game state aside: %w", err)
+			}
+		}
+
+		if err := writeFileAtomic(gss.savePath, data, 0644); err != nil {
+			return "", fmt.Errorf("failed to restore snapshot: %w", err)
+		}
+
+		// Drop anything cached from the broken file
+		gss.cacheLock.Lock()
+		gss.state = nil
+		gss.cacheLock.Unlock()
+
+		return snapshots[i], nil
+	}
+
+	return "", errors.New("no valid snapshot found")
+}
+
+// cachedState returns the cached document, loading it from disk on first use.
+// Callers must hold cacheLock.
+func (gss *GameStateStorage) cachedState() (*types.GameState, error) {
+	if gss.state == nil {
+		state, err := gss.LoadGameState()
+		if err != nil {
+			return nil, err
+		}
+		gss.state = state
+	}
+	return gss.state, nil
+}
+
+// copyJSON deep-copies src into dst through JSON, so the cached document
+// never shares objects with the game manager
+func copyJSON(src, dst interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return fmt.Errorf("failed to marshal copy: %w", err)
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		return fmt.Errorf("failed to parse copy: %w", err)
+	}
+	return nil
+}
+
+// LoadPlayers returns copies of all persisted players keyed by phone number
+func (gss *GameStateStorage) LoadPlayers() (map[string]*types.Player, error) {
+	gss.cacheLock.Lock()
+	defer gss.cacheLock.Unlock()
+
+	state, err := gss.cachedState()
+	if err != nil {
+		return nil, err
+	}
+
+	players := make(map[string]*types.Player, len(state.Players))
+	if err := copyJSON(state.Players, &players); err != nil {
+		return nil, err
+	}
+	return players, nil
+}
+
+// SavePlayers rewrites the file with copies of the given players updated, so
+// later changes to them aren't visible until they are saved again
+func (gss *GameStateStorage) SavePlayers(players ...*types.Player) error {
+	gss.cacheLock.Lock()
+	defer gss.cacheLock.Unlock()
+
+	state, err := gss.cachedState()
+	if err != nil {
+		return err
+	}
+
+	for _, player := range players {
+		var saved types.Player
+		if err := copyJSON(player, &saved); err != nil {
+			return fmt.Errorf("failed to copy player %s: %w", player.PhoneNumber, err)
+		}
+		state.Players[player.PhoneNumber] = &saved
+	}
+
+	return gss.SaveGameState(state)
+}
+
+// SaveBatch rewrites the file once with copies of everything in the batch;
+// the decision history is saved along with the player
+func (gss *GameStateStorage) SaveBatch(batch Batch) error {
+	gss.cacheLock.Lock()
+	defer gss.cacheLock.Unlock()
+
+	state, err := gss.cachedState()
+	if err != nil {
+		return err
+	}
+
+	for _, player := range batch.Players {
+		var saved types.Player
+		if err := copyJSON(player, &saved); err != nil {
+			return fmt.Errorf("failed to copy player %s: %w", player.PhoneNumber, err)
+		}
+		state.Players[player.PhoneNumber] = &saved
+	}
+
+	for _, faction := range batch.Factions {
+		var saved types.Faction
+		if err := copyJSON(faction, &saved); err != nil {
+			return fmt.Errorf("failed to copy faction %s: %w", faction.ID, err)
+		}
+		state.Factions[faction.ID] = &saved
+	}
+
+	for _, id := range batch.DeletedFactions {
+		delete(state.Factions, id)
+	}
+
+	for _, subZoneState := range batch.World {
+		var saved types.SubZoneState
+		if err := copyJSON(subZoneState, &saved); err != nil {
+			return fmt.Errorf("failed to copy subzone %s/%s: %w", subZoneState.Zone, subZoneState.SubZone, err)
+		}
+		state.World[subZoneKey(subZoneState.Zone, subZoneState.SubZone)] = &saved
+	}
+
+	return gss.SaveGameState(state)
+}
+
+// LoadCatalog returns copies of the persisted characters, events, actions,
+// zones and evolutions
+func (gss *GameStateStorage) LoadCatalog() (*types.GameState, error) {
+	gss.cacheLock.Lock()
+	defer gss.cacheLock.Unlock()
+
+	state, err := gss.cachedState()
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := newEmptyGameState()
+	if err := copyCatalog(state, catalog); err != nil {
+		return nil, err
+	}
+	return catalog, nil
+}
+
+// SaveCatalog rewrites the file with copies of the catalog of the given state
+func (gss *GameStateStorage) SaveCatalog(catalog *types.GameState) error {
+	gss.cacheLock.Lock()
+	defer gss.cacheLock.Unlock()
+
+	state, err := gss.cachedState()
+	if err != nil {
+		return err
+	}
+
+	if err := copyCatalog(catalog, state); err != nil {
+		return err
+	}
+
+	return gss.SaveGameState(state)
+}
+
+// copyCatalog deep-copies the characters, events, actions, zones and
+// evolutions of one state into another
+func copyCatalog(src, dst *types.GameState) error {
+	characters := make(map[string]*types.Character)
+	if err := copyJSON(src.Characters, &characters); err != nil {
+		return err
+	}
+	events := make(map[string]*types.Event)
+	if err := copyJSON(src.Events, &events); err != nil {
+		return err
+	}
+	actions := make(map[string]*types.Action)
+	if err := copyJSON(src.Actions, &actions); err != nil {
+		return err
+	}
+	zones := make(map[string]*types.Zone)
+	if err := copyJSON(src.Zones, &zones); err != nil {
+		return err
+	}
+	evolutions := make(map[string]*types.Evolution)
+	if err := copyJSON(src.Evolutions, &evolutions); err != nil {
+		return err
+	}
+
+	dst.Characters = characters
+	dst.Events = events
+	dst.Actions = actions
+	dst.Zones = zones
+	dst.Evolutions = evolutions
+	return nil
+}
+
+// LoadFactions returns copies of all persisted factions keyed by ID
+func (gss *GameStateStorage) LoadFactions() (map[string]*types.Faction, error) {
+	gss.cacheLock.Lock()
+	defer gss.cacheLock.Unlock()
+
+	state, err := gss.cachedState()
+	if err != nil {
+		return nil, err
+	}
+
+	factions := make(map[string]*types.Faction, len(state.Factions))
+	if err := copyJSON(state.Factions, &factions); err != nil {
+		return nil, err
+	}
+	return factions, nil
+}
+
+// SaveFactions rewrites the file with the given factions updated
+func (gss *GameStateStorage) SaveFactions(factions ...*types.Faction) error {
+	gss.cacheLock.Lock()
+	defer gss.cacheLock.Unlock()
+
+	state, err := gss.cachedState()
+	if err != nil {
+		return err
+	}
+
+	for _, faction := range factions {
+		var saved types.Faction
+		if err := copyJSON(faction, &saved); err != nil {
+			return fmt.Errorf("failed to copy faction %s: %w", faction.ID, err)
+		}
+		state.Factions[faction.ID] = &saved
+	}
+
+	return gss.SaveGameState(state)
+}
+
+// DeleteFaction rewrites the file without the given faction
+func (gss *GameStateStorage) DeleteFaction(id string) error {
+	gss.cacheLock.Lock()
+	defer gss.cacheLock.Unlock()
+
+	state, err := gss.cachedState()
+	if err != nil {
+		return err
+	}
+
+	delete(state.Factions, id)
+
+	return gss.SaveGameState(state)
+}
+
+// LoadWorld returns copies of the persisted dynamic state of the subzones
+func (gss *GameStateStorage) LoadWorld() (map[string]*types.SubZoneState, error) {
+	gss.cacheLock.Lock()
+	defer gss.cacheLock.Unlock()
+
+	state, err := gss.cachedState()
+	if err != nil {
+		return nil, err
+	}
+
+	world := make(map[string]*types.SubZoneState, len(state.World))
+	if err := copyJSON(state.World, &world); err != nil {
+		return nil, err
+	}
+	return world, nil
+}
+
+// SaveWorld rewrites the file with the given subzones updated
+func (gss *GameStateStorage) SaveWorld(states ...*types.SubZoneState) error {
+	gss.cacheLock.Lock()
+	defer gss.cacheLock.Unlock()
+
+	state, err := gss.cachedState()
+	if err != nil {
+		return err
+	}
+
+	for _, subZoneState := range states {
+		var saved types.SubZoneState
+		if err := copyJSON(subZoneState, &saved); err != nil {
+			return fmt.Errorf("failed to copy subzone %s/%s: %w", subZoneState.Zone, subZoneState.SubZone, err)
+		}
+		state.World[subZoneKey(subZoneState.Zone, subZoneState.SubZone)] = &saved
+	}
+
+	return gss.SaveGameState(state)
+}
+
+// Close is a no-op, the file is written on every save
+func (gss *GameStateStorage) Close() error {
+	return nil
+}
+
 // AutoPilotSystem handles automatic decision making for inactive players
 type AutoPilotSystem struct {
-	gameManager *GameManager
-	diceRoller  *DiceRoller
-	ticker      *time.Ticker
-	stopChan    chan struct{}
+	gameManager    *GameManager
+	diceRoller     *DiceRoller
+	decisionEngine *DecisionEngine
+	ticker         *time.Ticker
+	replyTimeout   time.Duration
+	stopChan       chan struct{}
+	doneChan       chan struct{}
+	logger         *zap.Logger
 }
 
-// NewAutoPilotSystem creates a new auto-pilot system
-func NewAutoPilotSystem(gameManager *GameManager, checkInterval time.Duration) *AutoPilotSystem {
+// NewAutoPilotSystem creates a new auto-pilot system. Players who leave an event
+// unanswered for longer than replyTimeout are taken over by the auto-pilot; a
+// non-positive timeout disables the takeover.
+func NewAutoPilotSystem(gameManager *GameManager, checkInterval, replyTimeout time.Duration, logger *zap.Logger) *AutoPilotSystem {
 	return &AutoPilotSystem{
-		gameManager: gameManager,
-		diceRoller:  NewDiceRoller(),
-		ticker:      time.NewTicker(checkInterval),
-		stopChan:    make(chan struct{}),
+		gameManager:    gameManager,
+		diceRoller:     NewDiceRoller(),
+		decisionEngine: NewDecisionEngine(),
+		ticker:         time.NewTicker(checkInterval),
+		replyTimeout:   replyTimeout,
+		stopChan:       make(chan struct{}),
+		doneChan:       make(chan struct{}),
+		logger:         logger,
 	}
 }
 
 // Start begins the auto-pilot system
 func (aps *AutoPilotSystem) Start() {
+	aps.logger.Info("Starting auto-pilot system",
+		zap.Duration("reply_timeout", aps.replyTimeout))
+
 	go func() {
+		defer close(aps.doneChan)
+
+		// Check for unanswered events often enough to honor the reply timeout
+		var timeoutChan <-chan time.Time
+		if aps.replyTimeout > 0 {
+			timeoutTicker := time.NewTicker(aps.replyTimeout / 2)
+			defer timeoutTicker.Stop()
+			timeoutChan = timeoutTicker.C
+		}
+
 		for {
 			select {
 			case <-aps.ticker.C:
 				aps.processAutoPilotPlayers()
+			case <-timeoutChan:
+				aps.takeOverInactivePlayers()
 			case <-aps.stopChan:
+				aps.logger.Info("Auto-pilot system received stop signal")
 				aps.ticker.Stop()
 				return
 			}
@@ -145,16 +737,132 @@ func (aps *AutoPilotSystem) Start() {
 	}()
 }
 
-// Stop halts the auto-pilot system
+// Stop halts the auto-pilot system, waiting for a running turn to finish
 func (aps *AutoPilotSystem) Stop() {
+	aps.logger.Info("Stopping auto-pilot system")
 	close(aps.stopChan)
+	<-aps.doneChan
 }
 
-// processAutoPilotPlayers handles decision making for players in auto-pilot mode
+// processAutoPilotPlayers handles decision making for players in auto-pilot
+// mode, deciding from copies of the players taken under the state lock
 func (aps *AutoPilotSystem) processAutoPilotPlayers() {
-	// This would check all players in auto-pilot mode and make decisions
-	// based on their character's attributes and preferences
-	// For now, this is a placeholder
+	for _, player := range aps.gameManager.autoPilotPlayers() {
+		entry, err := aps.playTurn(player)
+		if err != nil {
+			aps.logger.Error("Failed to play auto-pilot turn",
+				zap.String("phone_number", player.PhoneNumber),
+				zap.String("name", player.Name),
+				zap.Error(err))
+			continue
+		}
+
+		if entry == "" {
+			continue
+		}
+
+		// Let the player know what the bot did on their behalf
+		message := "🤖 *PILOTO AUTOMÁTICO* 🤖\n\n" +
+			"Enquanto você estava fora, eu joguei por você:\n\n" +
+			entry + "\n\n" +
+			"Mande qualquer mensagem para assumir o controle de novo! 🎮"
+
+		if err := aps.gameManager.SendMessage(player.PhoneNumber, message); err != nil {
+			aps.logger.Error("Failed to send auto-pilot digest",
+				zap.String("phone_number", player.PhoneNumber),
+				zap.String("name", player.Name),
+				zap.Error(err))
+		}
+	}
+}
+
+// takeOverInactivePlayers switches active players who left an event
+// unanswered past the reply timeout to auto-pilot and resolves the event.
+// An event answered after the switch, or replaced, is left alone.
+func (aps *AutoPilotSystem) takeOverInactivePlayers() {
+	for _, player := range aps.gameManager.takeOverIdlePlayers(aps.replyTimeout, time.Now()) {
+		aps.logger.Info("Player ignored event, switched to auto-pilot",
+			zap.String("phone_number", player.PhoneNumber),
+			zap.String("name", player.Name),
+			zap.String("event_id", player.CurrentEvent.ID),
+			zap.Time("event_sent_at", player.LastEventAt))
+
+		entry, err := aps.playTurn(player)
+		if errors.Is(err, errNoPendingEvent) || errors.Is(err, errEventNotPending) {
+			aps.logger.Info("Event answered before the auto-pilot got to it",
+				zap.String("phone_number", player.PhoneNumber))
+			continue
+		}
+		if err != nil {
+			aps.logger.Error("Failed to auto-resolve ignored event",
+				zap.String("phone_number", player.PhoneNumber),
+				zap.Error(err))
+			continue
+		}
+
+		message := "⏰ *O TEMPO ACABOU* ⏰\n\n" +
+			"Você não respondeu o evento a tempo, então o piloto automático assumiu:\n\n" +
+			entry + "\n\n" +
+			"Mande qualquer mensagem para voltar ao controle! 🎮"
+
+		if err := aps.gameManager.SendMessage(player.PhoneNumber, message); err != nil {
+			aps.logger.Error("Failed to send auto-pilot takeover message",
+				zap.String("phone_number", player.PhoneNumber),
+				zap.Error(err))
+		}
+	}
+}
+
+// playTurn plays a single turn for a player in auto-pilot mode and returns a
+// description of what was done. A pending event is always resolved first; only
+// when there is nothing to answer is the turn spent on an action. The player
+// is a copy, so reading it doesn't race the game.
+func (aps *AutoPilotSystem) playTurn(player *types.Player) (string, error) {
+	if event := player.CurrentEvent; event != nil {
+		option := aps.decisionEngine.ChooseEventOption(player, event)
+		if option == nil {
+			return "", fmt.Errorf("no option available for event %s", event.ID)
+		}
+
+		outcome, err := aps.gameManager.ProcessEventChoice(player.PhoneNumber, event.ID, option.ID)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve event %s: %w", event.ID, err)
+		}
+
+		aps.logger.Info("Auto-pilot resolved event",
+			zap.String("phone_number", player.PhoneNumber),
+			zap.String("event_id", event.ID),
+			zap.String("option_id", option.ID))
+
+		return fmt.Sprintf("🎭 *%s*\nEscolha: %s\n%s%s",
+			eventTitle(event), option.Description, outcome.Description, formatOutcomeChanges(outcome)), nil
+	}
+
+	// Nothing to do on the road but wait
+	if player.Travel != nil {
+		return "", nil
+	}
+
+	actions, err := aps.gameManager.GetAvailableActions(player.PhoneNumber)
+	if err != nil {
+		return "", fmt.Errorf("failed to get available actions: %w", err)
+	}
+
+	action := aps.decisionEngine.ChooseAction(player.CurrentCharacter, actions)
+	if action == nil {
+		return "", nil
+	}
+
+	outcome, err := aps.gameManager.PerformAction(player.PhoneNumber, action.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to perform action %s: %w", action.ID, err)
+	}
+
+	aps.logger.Info("Auto-pilot performed action",
+		zap.String("phone_number", player.PhoneNumber),
+		zap.String("action_id", action.ID))
+
+	return fmt.Sprintf("🎯 */%s*\n%s%s", action.Name, outcome.Description, formatOutcomeChanges(outcome)), nil
 }
 
 // DecisionEngine provides AI-based decision making for auto-pilot mode
@@ -194,8 +902,8 @@ func (de *DecisionEngine) ChooseAction(character *types.Character, availableActi
 	return availableActions[de.diceRoller.Roll(len(availableActions))-1]
 }
 
-// ChooseEventOption selects an option for an event based on character attributes
-func (de *DecisionEngine) ChooseEventOption(character *types.Character, event *types.Event) *types.EventOption {
+// ChooseEventOption selects an option for an event based on the player's attributes
+func (de *DecisionEngine) ChooseEventOption(player *types.Player, event *types.Event) *types.EventOption {
 	if len(event.Options) == 0 {
 		return nil
 	}
@@ -210,19 +918,8 @@ func (de *DecisionEngine) ChooseEventOption(character *types.Character, event *t
 	for _, option := range event.Options {
 		score := 0
 
-		// Check which attribute is required and add corresponding character attribute value
-		switch option.RequiredAttribute {
-		case "carisma":
-			score += character.Carisma * 2
-		case "proficiencia":
-			score += character.Proficiencia * 2
-		case "rede":
-			score += character.Rede * 2
-		case "moralidade":
-			score += character.Moralidade * 2
-		case "resiliencia":
-			score += character.Resiliencia * 2
-		}
+		// Add the player's value for the required attribute
+		score += playerAttribute(player, option.RequiredAttribute) * 2
 
 		// Adjust score based on difficulty (higher difficulty = lower score)
 		score -= option.DifficultyLevel
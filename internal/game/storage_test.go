@@ -0,0 +1,111 @@
+package game
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/user/vida-loka-strategy/internal/types"
+)
+
+// saveTestState saves a state with a single player with the given money
+func saveTestState(t *testing.T, storage *GameStateStorage, money int) {
+	t.Helper()
+
+	state := newEmptyGameState()
+	state.Players["5521999990000"] = &types.Player{PhoneNumber: "5521999990000", Name: "Ana", Money: money}
+	if err := storage.SaveGameState(state); err != nil {
+		t.Fatalf("failed to save state: %v", err)
+	}
+
+	// Snapshots are named by the millisecond
+	time.Sleep(5 * time.Millisecond)
+}
+
+func TestGameStateStorageRestoresLatestSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "game_state.json")
+
+	storage := NewGameStateStorage(path, 3, 0)
+	saveTestState(t, storage, 100)
+	saveTestState(t, storage, 200)
+
+	// A crash mid-write leaves the save truncated
+	if err := os.Truncate(path, 10); err != nil {
+		t.Fatalf("failed to truncate state: %v", err)
+	}
+
+	storage = NewGameStateStorage(path, 3, 0)
+	if _, err := storage.LoadGameState(); !errors.Is(err, ErrCorruptState) {
+		t.Fatalf("load error = %v, want ErrCorruptState", err)
+	}
+	if _, err := storage.LoadPlayers(); !errors.Is(err, ErrCorruptState) {
+		t.Fatalf("load players error = %v, want ErrCorruptState", err)
+	}
+
+	snapshot, err := storage.RestoreLatestSnapshot()
+	if err != nil {
+		t.Fatalf("failed to restore snapshot: %v", err)
+	}
+
+	snapshots, err := storage.listSnapshots()
+	if err != nil {
+		t.Fatalf("failed to list snapshots: %v", err)
+	}
+	if len(snapshots) != 2 || snapshot != snapshots[1] {
+		t.Errorf("restored %s, want the latest of %v", snapshot, snapshots)
+	}
+
+	players, err := storage.LoadPlayers()
+	if err != nil {
+		t.Fatalf("failed to load restored players: %v", err)
+	}
+	if player := players["5521999990000"]; player == nil || player.Money != 200 {
+		t.Errorf("restored player = %+v, want R$ 200", player)
+	}
+
+	// The broken save is kept for inspection
+	if matches, _ := filepath.Glob(path + ".corrupt-*"); len(matches) != 1 {
+		t.Errorf("corrupt files = %v, want 1", matches)
+	}
+}
+
+func TestGameStateStorageSkipsCorruptSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "game_state.json")
+
+	storage := NewGameStateStorage(path, 3, 0)
+	saveTestState(t, storage, 100)
+	saveTestState(t, storage, 200)
+
+	snapshots, err := storage.listSnapshots()
+	if err != nil || len(snapshots) != 2 {
+		t.Fatalf("snapshots = %v (%v), want 2", snapshots, err)
+	}
+
+	// Both the save and its newest snapshot are broken
+	if err := os.Truncate(path, 10); err != nil {
+		t.Fatalf("failed to truncate state: %v", err)
+	}
+	if err := os.Truncate(snapshots[1], 10); err != nil {
+		t.Fatalf("failed to truncate snapshot: %v", err)
+	}
+
+	snapshot, err := storage.RestoreLatestSnapshot()
+	if err != nil {
+		t.Fatalf("failed to restore snapshot: %v", err)
+	}
+	if snapshot != snapshots[0] {
+		t.Errorf("restored %s, want %s", snapshot, snapshots[0])
+	}
+
+	state, err := storage.LoadGameState()
+	if err != nil {
+		t.Fatalf("failed to load restored state: %v", err)
+	}
+	if player := state.Players["5521999990000"]; player == nil || player.Money != 100 {
+		t.Errorf("restored player = %+v, want R$ 100", player)
+	}
+}
@@ -0,0 +1,100 @@
+package game
+
+import (
+	"github.com/user/vida-loka-strategy/internal/types"
+)
+
+// stressBand is a range of stress with its effect on the game. Events can be
+// tied to a band with their stress_band field.
+type stressBand struct {
+	// ID used by events and shown in the status
+	ID string
+
+	// Display name
+	Name string
+
+	// Lowest stress in the band
+	Min int
+
+	// Added to the d20 roll of event choices
+	RollPenalty int
+
+	// Percentage of the XP, money and influence gains kept from actions
+	YieldPercent int
+}
+
+// stressBands lists the bands from the calmest up; burnout isn't reached by
+// stress alone, see updateBurnout
+var stressBands = []stressBand{
+	{ID: "calmo", Name: "Calmo", Min: 0, RollPenalty: 0, YieldPercent: 100},
+	{ID: "tenso", Name: "Tenso", Min: 40, RollPenalty: -1, YieldPercent: 90},
+	{ID: "estressado", Name: "Estressado", Min: 60, RollPenalty: -3, YieldPercent: 75},
+	{ID: "no_limite", Name: "No limite", Min: 80, RollPenalty: -5, YieldPercent: 50},
+}
+
+// burnoutBand applies to players in burnout, whatever their stress
+var burnoutBand = stressBand{ID: "burnout", Name: "Burnout", Min: 100, RollPenalty: -8, YieldPercent: 25}
+
+// playerStressBand returns the band a player is in
+func playerStressBand(player *types.Player) stressBand {
+	if player.Burnout {
+		return burnoutBand
+	}
+
+	band := stressBands[0]
+	for _, b := range stressBands {
+		if player.Stress >= b.Min {
+			band = b
+		}
+	}
+	return band
+}
+
+// applyStressYield scales down the gains of an outcome by the player's stress
+// band; losses are kept as they are
+func applyStressYield(outcome *types.Outcome, band stressBand) {
+	scale := func(value int) int {
+		if value <= 0 {
+			return value
+		}
+		return value * band.YieldPercent / 100
+	}
+
+	outcome.XPChange = scale(outcome.XPChange)
+	outcome.MoneyChange = scale(outcome.MoneyChange)
+	outcome.InfluenceChange = scale(outcome.InfluenceChange)
+}
+
+// eventMatchesStressBand reports whether an event can happen to the player:
+// events tied to a stress band only happen to players in that band
+func eventMatchesStressBand(event *types.Event, player *types.Player) bool {
+	return event.StressBand == "" || event.StressBand == playerStressBand(player).ID
+}
+
+// isBurnoutRecoveryAction reports whether an action is allowed during burnout
+func (gm *GameManager) isBurnoutRecoveryAction(actionID string) bool {
+	for _, id := range gm.config.Game.BurnoutRecoveryActions {
+		if id == actionID {
+			return true
+		}
+	}
+	return false
+}
+
+// updateBurnout clamps the player's stress and puts them in or out of
+// burnout: maxing out stress burns the player out, and they only recover once
+// resting brings stress down to the configured level
+func (gm *GameManager) updateBurnout(player *types.Player) {
+	if player.Stress < 0 {
+		player.Stress = 0
+	}
+	if player.Stress > 100 {
+		player.Stress = 100
+	}
+
+	if player.Stress >= 100 {
+		player.Burnout = true
+	} else if player.Burnout && player.Stress <= gm.config.Game.BurnoutRecoveryStress {
+		player.Burnout = false
+	}
+}
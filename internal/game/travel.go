@@ -0,0 +1,333 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/user/vida-loka-strategy/internal/types"
+	"go.uber.org/zap"
+)
+
+// transitEventType marks events that only happen on the way somewhere
+const transitEventType = "transit"
+
+// findSubZone returns the subzone with the given ID in a zone, or nil
+func findSubZone(zone *types.Zone, subZoneID string) *types.SubZone {
+	for i := range zone.SubZones {
+		if zone.SubZones[i].ID == subZoneID {
+			return &zone.SubZones[i]
+		}
+	}
+	return nil
+}
+
+// subZoneDisplayName returns "Zone, SubZone" for a location, falling back to
+// the IDs. Callers must hold stateLock.
+func (gm *GameManager) subZoneDisplayName(zoneID, subZoneID string) string {
+	zone, exists := gm.state.Zones[zoneID]
+	if !exists {
+		return fmt.Sprintf("%s, %s", zoneID, subZoneID)
+	}
+	if subZone := findSubZone(zone, subZoneID); subZone != nil {
+		return fmt.Sprintf("%s, %s", zone.Name, subZone.Name)
+	}
+	return fmt.Sprintf("%s, %s", zone.Name, subZoneID)
+}
+
+// QuoteTravel prices a trip from the player's current location to a subzone.
+// Crossing zones costs more money and stress and takes longer than moving
+// within the same zone; pricier destinations (higher reward multiplier) cost
+// more to reach. The quote is kept on the player until ConfirmTravel.
+func (gm *GameManager) QuoteTravel(phoneNumber, zoneID, subZoneID string) (*types.Travel, error) {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	player, exists := gm.state.Players[phoneNumber]
+	if !exists {
+		return nil, errors.New("jogador não encontrado")
+	}
+
+	if player.CurrentCharacter == nil {
+		return nil, errors.New("jogador não selecionou um personagem")
+	}
+
+	if player.Travel != nil {
+		return nil, errors.New("você já está em trânsito")
+	}
+
+	// Burned out players can only rest
+	if player.Burnout {
+		return nil, errors.New("você está em burnout")
+	}
+
+	zone, exists := gm.state.Zones[zoneID]
+	if !exists {
+		return nil, fmt.Errorf("zona não encontrada: %s", zoneID)
+	}
+
+	subZone := findSubZone(zone, subZoneID)
+	if subZone == nil {
+		return nil, fmt.Errorf("subzona não encontrada: %s", subZoneID)
+	}
+
+	if player.CurrentZone == zoneID && player.CurrentSubZone == subZoneID {
+		return nil, errors.New("você já está aqui")
+	}
+
+	cost := gm.config.Game.TravelCostSameZone
+	stress := gm.config.Game.TravelStressSameZone
+	minutes := gm.config.Game.TravelTimeSameZone
+	if player.CurrentZone != zoneID {
+		cost = gm.config.Game.TravelCostCrossZone
+		stress = gm.config.Game.TravelStressCrossZone
+		minutes = gm.config.Game.TravelTimeCrossZone
+	}
+
+	// Getting to the nice places costs more
+	if subZone.RewardMultiplier > 0 {
+		cost = cost * subZone.RewardMultiplier / 100
+	}
+
+	quote := &types.Travel{
+		FromZone:        player.CurrentZone,
+		FromSubZone:     player.CurrentSubZone,
+		ToZone:          zoneID,
+		ToSubZone:       subZoneID,
+		Cost:            cost,
+		StressCost:      stress,
+		DurationMinutes: minutes,
+		RiskLevel:       gm.subZoneRisk(zoneID, subZone),
+		QuotedAt:        time.Now(),
+	}
+	player.TravelQuote = quote
+
+	// Queue the player for the next save
+	gm.markDirty(player)
+
+	quoteCopy := *quote
+	return &quoteCopy, nil
+}
+
+// ConfirmTravel starts the trip quoted by the last QuoteTravel, charging its
+// cost and stress. The player stays in transit until the travel system
+// completes the trip.
+func (gm *GameManager) ConfirmTravel(phoneNumber string) (*types.Travel, error) {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	player, exists := gm.state.Players[phoneNumber]
+	if !exists {
+		return nil, errors.New("jogador não encontrado")
+	}
+
+	if player.Travel != nil {
+		return nil, errors.New("você já está em trânsito")
+	}
+
+	// Burned out players can only rest
+	if player.Burnout {
+		return nil, errors.New("você está em burnout")
+	}
+
+	quote := player.TravelQuote
+	if quote == nil {
+		return nil, errors.New("nenhuma viagem para confirmar")
+	}
+
+	// A quote is only good from where it was made
+	if quote.FromZone != player.CurrentZone || quote.FromSubZone != player.CurrentSubZone {
+		player.TravelQuote = nil
+		gm.markDirty(player)
+		return nil, errors.New("você mudou de lugar desde o orçamento, peça outro")
+	}
+
+	if player.Money < quote.Cost {
+		return nil, fmt.Errorf("dinheiro insuficiente: a viagem custa R$ %d,00", quote.Cost)
+	}
+
+	player.Money -= quote.Cost
+	player.Stress += quote.StressCost
+	gm.updateBurnout(player)
+
+	now := time.Now()
+	travel := *quote
+	travel.StartedAt = now
+	// Trips take game time, so they get shorter as the clock runs faster
+	travel.ArrivesAt = now.Add(gm.realDuration(travel.DurationMinutes))
+
+	player.Travel = &travel
+	player.TravelQuote = nil
+	player.LastActiveAt = now
+
+	// Queue the player for the next save
+	gm.markDirty(player)
+
+	travelCopy := travel
+	return &travelCopy, nil
+}
+
+// arrival is a finished trip waiting to be announced to its player, captured
+// under stateLock
+type arrival struct {
+	phoneNumber string
+	travel      types.Travel
+	message     string
+	event       *types.Event
+
+	// Players on auto-pilot get their transit event answered, not messaged
+	notify bool
+}
+
+// completeTravels moves every player whose trip is due to its destination and
+// rolls a transit event for each, with a chance that grows with the
+// destination's risk level
+func (gm *GameManager) completeTravels(now time.Time) []arrival {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	var arrivals []arrival
+	for _, player := range gm.state.Players {
+		if player.Travel == nil || now.Before(player.Travel.ArrivesAt) {
+			continue
+		}
+
+		travel := *player.Travel
+		player.CurrentZone = travel.ToZone
+		player.CurrentSubZone = travel.ToSubZone
+		player.Travel = nil
+
+		a := arrival{phoneNumber: player.PhoneNumber, travel: travel, notify: player.Status != "autopilot"}
+
+		if zone, exists := gm.state.Zones[travel.ToZone]; exists {
+			if subZone := findSubZone(zone, travel.ToSubZone); subZone != nil {
+				a.message = fmt.Sprintf("Você chegou em *%s* 🏃‍♂️", subZone.Name)
+				if len(subZone.ArrivalMessages) > 0 {
+					a.message = subZone.ArrivalMessages[rand.Intn(len(subZone.ArrivalMessages))]
+				}
+			}
+		}
+
+		// Something may have happened on the way
+		chance := travel.RiskLevel * gm.config.Game.TransitEventChance
+		if player.CurrentEvent == nil && rand.Intn(100) < chance {
+			if event := gm.pickTransitEvent(travel); event != nil {
+				eventCopy := *event
+				player.CurrentEvent = &eventCopy
+				player.LastEventAt = now
+				a.event = &eventCopy
+			}
+		}
+
+		// Queue the player for the next save
+		gm.markDirty(player)
+
+		arrivals = append(arrivals, a)
+	}
+
+	return arrivals
+}
+
+// pickTransitEvent draws a transit event allowed at the trip's risk level and
+// destination, or nil if there is none. Callers must hold stateLock.
+func (gm *GameManager) pickTransitEvent(travel types.Travel) *types.Event {
+	var eligible []*types.Event
+	for _, event := range gm.state.Events {
+		if event.Type != transitEventType || event.MinRisk > travel.RiskLevel {
+			continue
+		}
+
+		if len(event.RequiredZone) > 0 {
+			zoneMatch := false
+			for _, zone := range event.RequiredZone {
+				if zone == travel.ToZone {
+					zoneMatch = true
+					break
+				}
+			}
+			if !zoneMatch {
+				continue
+			}
+		}
+
+		eligible = append(eligible, event)
+	}
+
+	if len(eligible) == 0 {
+		return nil
+	}
+
+	return eligible[rand.Intn(len(eligible))]
+}
+
+// TravelSystem completes trips when they arrive and tells the players
+type TravelSystem struct {
+	gameManager *GameManager
+	ticker      *time.Ticker
+	stopChan    chan struct{}
+	doneChan    chan struct{}
+	logger      *zap.Logger
+}
+
+// NewTravelSystem creates a new travel system
+func NewTravelSystem(gameManager *GameManager, checkInterval time.Duration, logger *zap.Logger) *TravelSystem {
+	return &TravelSystem{
+		gameManager: gameManager,
+		ticker:      time.NewTicker(checkInterval),
+		stopChan:    make(chan struct{}),
+		doneChan:    make(chan struct{}),
+		logger:      logger,
+	}
+}
+
+// Start begins the travel system
+func (ts *TravelSystem) Start() {
+	go func() {
+		defer close(ts.doneChan)
+
+		for {
+			select {
+			case <-ts.ticker.C:
+				ts.processArrivals()
+			case <-ts.stopChan:
+				ts.ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the travel system, waiting for a running check to finish.
+// Trips still on the road complete on the next start.
+func (ts *TravelSystem) Stop() {
+	close(ts.stopChan)
+	<-ts.doneChan
+}
+
+// processArrivals completes the trips that are due and messages each player
+func (ts *TravelSystem) processArrivals() {
+	for _, a := range ts.gameManager.completeTravels(time.Now()) {
+		ts.logger.Info("Player arrived",
+			zap.String("phone_number", a.phoneNumber),
+			zap.String("zone", a.travel.ToZone),
+			zap.String("sub_zone", a.travel.ToSubZone),
+			zap.Bool("transit_event", a.event != nil))
+
+		// The auto-pilot answers transit events in its next turn
+		if !a.notify {
+			continue
+		}
+
+		message := a.message + "\n\nBem-vindo ao seu novo cantinho! 🏠✨"
+		if a.event != nil {
+			message += "\n\nMas no caminho...\n\n" + formatEventMessage(a.event)
+		}
+
+		if err := ts.gameManager.SendMessage(a.phoneNumber, message); err != nil {
+			ts.logger.Error("Failed to send arrival message",
+				zap.String("phone_number", a.phoneNumber),
+				zap.Error(err))
+		}
+	}
+}
@@ -2,10 +2,12 @@ package game
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/user/vida-loka-strategy/config"
@@ -57,6 +59,82 @@ func (dl *DataLoader) LoadEvents() ([]*types.Event, error) {
 	return events, nil
 }
 
+// LoadEvolutions loads evolution definitions from file
+func (dl *DataLoader) LoadEvolutions() ([]*types.Evolution, error) {
+	path := filepath.Join(dl.basePath, "evolutions.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read evolutions file: %w", err)
+	}
+
+	var evolutions []*types.Evolution
+	if err := json.Unmarshal(data, &evolutions); err != nil {
+		return nil, fmt.Errorf("failed to parse evolutions data: %w", err)
+	}
+
+	return evolutions, nil
+}
+
+// LoadMissions loads group mission definitions from file
+func (dl *DataLoader) LoadMissions() ([]*types.Mission, error) {
+	path := filepath.Join(dl.basePath, "missions.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read missions file: %w", err)
+	}
+
+	var missions []*types.Mission
+	if err := json.Unmarshal(data, &missions); err != nil {
+		return nil, fmt.Errorf("failed to parse missions data: %w", err)
+	}
+
+	// A mission is played step by step, each voted among its options
+	for _, mission := range missions {
+		if len(mission.Steps) == 0 {
+			return nil, fmt.Errorf("mission %s has no steps", mission.ID)
+		}
+		for i, step := range mission.Steps {
+			if len(step.Options) == 0 {
+				return nil, fmt.Errorf("step %d of mission %s has no options", i+1, mission.ID)
+			}
+		}
+	}
+
+	return missions, nil
+}
+
+// LoadSeasons loads the calendar of seasons from file
+func (dl *DataLoader) LoadSeasons() ([]*types.Season, error) {
+	path := filepath.Join(dl.basePath, "seasons.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seasons file: %w", err)
+	}
+
+	var seasons []*types.Season
+	if err := json.Unmarshal(data, &seasons); err != nil {
+		return nil, fmt.Errorf("failed to parse seasons data: %w", err)
+	}
+
+	return seasons, nil
+}
+
+// LoadWorldEvents loads the world events from file
+func (dl *DataLoader) LoadWorldEvents() ([]*types.WorldEvent, error) {
+	path := filepath.Join(dl.basePath, "world_events.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read world events file: %w", err)
+	}
+
+	var events []*types.WorldEvent
+	if err := json.Unmarshal(data, &events); err != nil {
+		return nil, fmt.Errorf("failed to parse world events data: %w", err)
+	}
+
+	return events, nil
+}
+
 // LoadActions loads action definitions from file
 func (dl *DataLoader) LoadActions() ([]*types.Action, error) {
 	path := filepath.Join(dl.basePath, "actions.json")
@@ -116,25 +194,32 @@ func (dr *DiceRoller) RollD20WithAttribute(attribute int) int {
 	return dr.RollWithBonus(20, attribute)
 }
 
-// EventSystem handles scheduling and triggering of game events
+// EventSystem handles scheduling and triggering of game events. Each player
+// has their own next check, kept in a queue ordered by due time; the ticker
+// only looks for checks that are due.
 type EventSystem struct {
 	gameManager *GameManager
 	ticker      *time.Ticker
 	stopChan    chan struct{}
+	doneChan    chan struct{}
 	logger      *zap.Logger
 	diceRoller  *DiceRoller
 	config      *config.Config
+	queue       eventQueue
+	scheduled   map[string]*scheduledCheck
 }
 
 // NewEventSystem creates a new event system
-func NewEventSystem(gameManager *GameManager, eventInterval time.Duration, logger *zap.Logger, diceRoller *DiceRoller, config *config.Config) *EventSystem {
+func NewEventSystem(gameManager *GameManager, checkInterval time.Duration, logger *zap.Logger, diceRoller *DiceRoller, config *config.Config) *EventSystem {
 	return &EventSystem{
 		gameManager: gameManager,
-		ticker:      time.NewTicker(eventInterval),
+		ticker:      time.NewTicker(checkInterval),
 		stopChan:    make(chan struct{}),
+		doneChan:    make(chan struct{}),
 		logger:      logger,
 		diceRoller:  diceRoller,
 		config:      config,
+		scheduled:   make(map[string]*scheduledCheck),
 	}
 }
 
@@ -142,14 +227,17 @@ func NewEventSystem(gameManager *GameManager, eventInterval time.Duration, logge
 func (es *EventSystem) Start() {
 	es.logger.Info("Starting event system",
 		zap.Int("event_interval_minutes", es.config.Game.EventInterval),
+		zap.Int("event_jitter_percent", es.config.Game.EventJitter),
 		zap.Int("event_probability", es.config.Game.RandomEventProbability))
 
 	go func() {
+		defer close(es.doneChan)
+
 		for {
 			select {
 			case <-es.ticker.C:
 				es.logger.Debug("Event system tick received")
-				es.triggerEvents()
+				es.processDueEvents(time.Now())
 			case <-es.stopChan:
 				es.logger.Info("Event system received stop signal")
 				es.ticker.Stop()
@@ -159,108 +247,237 @@ func (es *EventSystem) Start() {
 	}()
 }
 
-// Stop halts the event scheduling system
+// Stop halts the event scheduling system, waiting for a running tick to finish
 func (es *EventSystem) Stop() {
 	es.logger.Info("Stopping event system")
 	close(es.stopChan)
+	<-es.doneChan
 }
 
 // formatEventMessage formats an event into a WhatsApp message
 func formatEventMessage(event *types.Event) string {
 	message := fmt.Sprintf("🎭 *EVENTO ALEATÓRIO* 🎭\n\n")
+	if event.Encounter != nil {
+		message = fmt.Sprintf("🐺 *ENCONTRO COM %s* 🐺\n\n", strings.ToUpper(event.Encounter.PredatorName))
+	}
 	message += fmt.Sprintf("%s\n\n", event.Description)
 
 	if len(event.Options) > 0 {
 		message += "Escolha sua ação:\n"
+		commands := make([]string, len(event.Options))
 		for i, option := range event.Options {
-			message += fmt.Sprintf("%s. %s\n", string('A'+i), option.Description)
+			message += fmt.Sprintf("%s. %s\n", string(rune('A'+i)), option.Description)
+			commands[i] = fmt.Sprintf("*/%s*", string(rune('a'+i)))
 		}
-		message += "\nResponda com */a*, */b*, */c* ou */d* para escolher sua ação! 🎲"
+
+		answer := commands[0]
+		if len(commands) > 1 {
+			answer = strings.Join(commands[:len(commands)-1], ", ") + " ou " + commands[len(commands)-1]
+		}
+		message += fmt.Sprintf("\nResponda com %s para escolher sua ação! 🎲", answer)
 	}
 
 	return message
 }
 
-// triggerEvents checks for and triggers events for all active players
-func (es *EventSystem) triggerEvents() {
-	es.logger.Info("Starting event check cycle")
+// eventTitle returns the display title of an event, falling back to its name or ID
+func eventTitle(event *types.Event) string {
+	if event.Title != "" {
+		return event.Title
+	}
+	if event.Name != "" {
+		return event.Name
+	}
+	return event.ID
+}
+
+// isSystemEvent reports whether an event is only fired by its own system
+// (trips, encounters, event chains, seasons, world events) and never drawn
+// from the zone pools
+func isSystemEvent(event *types.Event) bool {
+	return event.Type == transitEventType || event.Type == encounterEventType ||
+		event.Type == chainEventType || event.Type == seasonalEventType ||
+		event.Type == worldEventType
+}
 
-	// Get all players
-	players := es.gameManager.GetAllPlayers()
-	es.logger.Info("Checking events for players", zap.Int("total_players", len(players)))
+// formatOutcomeChanges formats the stat changes of an outcome, one per line
+func formatOutcomeChanges(outcome *types.Outcome) string {
+	changes := ""
 
-	for _, player := range players {
-		// Skip inactive players
-		if player.Status != "active" {
-			es.logger.Info("Skipping inactive player",
-				zap.String("phone_number", player.PhoneNumber),
-				zap.String("name", player.Name),
-				zap.String("status", player.Status),
-				zap.String("location", fmt.Sprintf("%s, %s", player.CurrentZone, player.CurrentSubZone)))
+	if outcome.XPChange != 0 {
+		changes += fmt.Sprintf("\n⭐ XP: %+d", outcome.XPChange)
+	}
+
+	if outcome.MoneyChange != 0 {
+		changes += fmt.Sprintf("\n💰 Dinheiro: R$ %+d,00", outcome.MoneyChange)
+	}
+
+	if outcome.InfluenceChange != 0 {
+		changes += fmt.Sprintf("\n🎭 Influência: %+d", outcome.InfluenceChange)
+	}
+
+	if outcome.StressChange != 0 {
+		changes += fmt.Sprintf("\n💥 Estresse: %+d", outcome.StressChange)
+	}
+
+	return changes
+}
+
+// processDueEvents checks every player whose turn has come and schedules
+// their next check
+func (es *EventSystem) processDueEvents(now time.Time) {
+	es.syncSchedule(now)
+
+	for {
+		phoneNumber, due := es.popDue(now)
+		if !due {
+			return
+		}
+
+		player, err := es.gameManager.GetPlayer(phoneNumber)
+		if err != nil {
 			continue
 		}
 
-		es.logger.Info("Checking event for player",
+		es.checkPlayer(player, now)
+
+		next := es.gameManager.scheduleNextEvent(phoneNumber, now)
+		es.logger.Debug("Next event check scheduled",
+			zap.String("phone_number", phoneNumber),
+			zap.Time("next_event_at", next))
+		es.schedule(phoneNumber, next)
+	}
+}
+
+// checkPlayer rolls for an encounter and a random event for one player
+func (es *EventSystem) checkPlayer(player *types.Player, now time.Time) {
+	// Skip inactive players; auto-pilot players still get events, the bot answers them
+	if player.Status != "active" && player.Status != "autopilot" {
+		es.logger.Info("Skipping inactive player",
 			zap.String("phone_number", player.PhoneNumber),
 			zap.String("name", player.Name),
-			zap.String("location", fmt.Sprintf("%s, %s", player.CurrentZone, player.CurrentSubZone)),
-			zap.Int("xp", player.XP),
-			zap.Int("money", player.Money),
-			zap.Int("influence", player.Influence),
-			zap.Int("stress", player.Stress))
-
-		// Roll for event
-		roll := es.diceRoller.Roll(100)
-		required := es.config.Game.RandomEventProbability
-		eventTriggered := roll <= required
-
-		es.logger.Info("Event roll result",
+			zap.String("status", player.Status),
+			zap.String("location", fmt.Sprintf("%s, %s", player.CurrentZone, player.CurrentSubZone)))
+		return
+	}
+
+	// Don't overwrite an event the player hasn't answered yet
+	if player.CurrentEvent != nil {
+		es.logger.Info("Skipping player with pending event",
 			zap.String("phone_number", player.PhoneNumber),
 			zap.String("name", player.Name),
-			zap.Int("roll", roll),
-			zap.Int("required", required),
-			zap.Bool("event_triggered", eventTriggered))
+			zap.String("event_id", player.CurrentEvent.ID))
+		return
+	}
 
-		if eventTriggered {
-			es.logger.Info("Event triggered for player",
-				zap.String("phone_number", player.PhoneNumber),
-				zap.String("name", player.Name))
-
-			// Generate event
-			event, err := es.gameManager.TriggerRandomEvent(player.PhoneNumber)
-			if err != nil {
-				es.logger.Error("Failed to trigger event",
-					zap.String("phone_number", player.PhoneNumber),
-					zap.String("name", player.Name),
-					zap.Error(err))
-				continue
-			}
+	// Players on the road get their transit roll on arrival instead
+	if player.Travel != nil {
+		es.logger.Info("Skipping player in transit",
+			zap.String("phone_number", player.PhoneNumber),
+			zap.String("name", player.Name))
+		return
+	}
 
-			es.logger.Info("Sending event message to player",
+	// Nothing happens to players resting or who turned events off, so no
+	// event sits unanswered until the auto-pilot takes over
+	if inQuietHours(player.Notifications, now) || isMuted(player.Notifications, categoryEvents) {
+		es.logger.Info("Skipping player not taking events",
+			zap.String("phone_number", player.PhoneNumber),
+			zap.String("name", player.Name))
+		return
+	}
+
+	es.logger.Info("Checking event for player",
+		zap.String("phone_number", player.PhoneNumber),
+		zap.String("name", player.Name),
+		zap.String("location", fmt.Sprintf("%s, %s", player.CurrentZone, player.CurrentSubZone)),
+		zap.Int("xp", player.XP),
+		zap.Int("money", player.Money),
+		zap.Int("influence", player.Influence),
+		zap.Int("stress", player.Stress))
+
+	// Natural predators around the player get the first chance
+	if es.diceRoller.Roll(100) <= es.config.Game.EncounterProbability {
+		event, err := es.gameManager.TriggerEncounter(player.PhoneNumber)
+		if err == nil {
+			es.logger.Info("Encounter triggered for player",
 				zap.String("phone_number", player.PhoneNumber),
 				zap.String("name", player.Name),
 				zap.String("event_id", event.ID),
-				zap.String("event_description", event.Description),
-				zap.Int("options_count", len(event.Options)))
-
-			// Format event message
-			message := formatEventMessage(event)
-
-			// Send event message using player's phone number
-			if err := es.gameManager.SendMessage(player.PhoneNumber, message); err != nil {
-				es.logger.Error("Failed to send event message",
-					zap.String("phone_number", player.PhoneNumber),
-					zap.String("name", player.Name),
-					zap.Error(err))
+				zap.String("predator", event.Encounter.PredatorID))
+
+			if player.Status != "autopilot" {
+				if err := es.gameManager.sendNotification(player.PhoneNumber, categoryEvents, formatEventMessage(event)); err != nil {
+					es.logger.Error("Failed to send encounter message",
+						zap.String("phone_number", player.PhoneNumber),
+						zap.String("name", player.Name),
+						zap.Error(err))
+				}
 			}
-		} else {
-			es.logger.Info("No event triggered for player",
+			return
+		}
+		if !errors.Is(err, ErrNoPredator) {
+			es.logger.Error("Failed to trigger encounter",
 				zap.String("phone_number", player.PhoneNumber),
 				zap.String("name", player.Name),
-				zap.Int("roll", roll),
-				zap.Int("required", required))
+				zap.Error(err))
 		}
 	}
 
-	es.logger.Info("Completed event check cycle")
+	// Roll for event
+	roll := es.diceRoller.Roll(100)
+	required := es.config.Game.RandomEventProbability
+	eventTriggered := roll <= required
+
+	es.logger.Info("Event roll result",
+		zap.String("phone_number", player.PhoneNumber),
+		zap.String("name", player.Name),
+		zap.Int("roll", roll),
+		zap.Int("required", required),
+		zap.Bool("event_triggered", eventTriggered))
+
+	if eventTriggered {
+		es.logger.Info("Event triggered for player",
+			zap.String("phone_number", player.PhoneNumber),
+			zap.String("name", player.Name))
+
+		// Generate event
+		event, err := es.gameManager.TriggerRandomEvent(player.PhoneNumber)
+		if err != nil {
+			es.logger.Error("Failed to trigger event",
+				zap.String("phone_number", player.PhoneNumber),
+				zap.String("name", player.Name),
+				zap.Error(err))
+			return
+		}
+
+		es.logger.Info("Sending event message to player",
+			zap.String("phone_number", player.PhoneNumber),
+			zap.String("name", player.Name),
+			zap.String("event_id", event.ID),
+			zap.String("event_description", event.Description),
+			zap.Int("options_count", len(event.Options)))
+
+		// The auto-pilot answers for the player and reports back in its digest
+		if player.Status == "autopilot" {
+			return
+		}
+
+		// Format event message
+		message := formatEventMessage(event)
+
+		// Send event message using player's phone number
+		if err := es.gameManager.sendNotification(player.PhoneNumber, categoryEvents, message); err != nil {
+			es.logger.Error("Failed to send event message",
+				zap.String("phone_number", player.PhoneNumber),
+				zap.String("name", player.Name),
+				zap.Error(err))
+		}
+	} else {
+		es.logger.Info("No event triggered for player",
+			zap.String("phone_number", player.PhoneNumber),
+			zap.String("name", player.Name),
+			zap.Int("roll", roll),
+			zap.Int("required", required))
+	}
 }
@@ -0,0 +1,226 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/user/vida-loka-strategy/internal/types"
+	"go.uber.org/zap"
+)
+
+// subZoneKey identifies a subzone in GameState.World
+func subZoneKey(zoneID, subZoneID string) string {
+	return zoneID + "/" + subZoneID
+}
+
+// subZoneState returns the dynamic state of a subzone, creating it the first
+// time the subzone is touched. Callers must hold stateLock.
+func (gm *GameManager) subZoneState(zoneID, subZoneID string) *types.SubZoneState {
+	key := subZoneKey(zoneID, subZoneID)
+	state, exists := gm.state.World[key]
+	if !exists {
+		state = &types.SubZoneState{Zone: zoneID, SubZone: subZoneID}
+		gm.state.World[key] = state
+	}
+	return state
+}
+
+// peekSubZoneState returns the dynamic state of a subzone, or a calm one for
+// subzones nobody touched yet, without adding it to the world. Callers must
+// hold stateLock.
+func (gm *GameManager) peekSubZoneState(zoneID, subZoneID string) types.SubZoneState {
+	if state, exists := gm.state.World[subZoneKey(zoneID, subZoneID)]; exists {
+		return *state
+	}
+	return types.SubZoneState{Zone: zoneID, SubZone: subZoneID}
+}
+
+// GetSubZoneState returns the heat, police presence and control of a subzone
+func (gm *GameManager) GetSubZoneState(zoneID, subZoneID string) types.SubZoneState {
+	gm.stateLock.RLock()
+	defer gm.stateLock.RUnlock()
+
+	return gm.peekSubZoneState(zoneID, subZoneID)
+}
+
+// recordSubZoneImpact shifts the subzone where the player is by what they just
+// did: the outcome's heat, and more control for the faction that owns the
+// place when the player is one of its members. Callers must hold stateLock.
+func (gm *GameManager) recordSubZoneImpact(player *types.Player, heat int) {
+	owner := gm.territoryOwner(player.CurrentZone, player.CurrentSubZone)
+	gainsControl := owner != nil && gm.playerFaction(player.PhoneNumber) == owner
+
+	if heat == 0 && !gainsControl {
+		return
+	}
+
+	state := gm.subZoneState(player.CurrentZone, player.CurrentSubZone)
+	state.Heat = clampPercent(state.Heat + heat)
+	if gainsControl {
+		state.Control = clampPercent(state.Control + gm.config.Game.TerritoryControlGain)
+	}
+	state.UpdatedAt = time.Now()
+
+	// Queue the subzone for the next save
+	gm.markWorldDirty(subZoneKey(player.CurrentZone, player.CurrentSubZone))
+}
+
+// subZoneRisk returns the risk of a subzone right now: its static risk level
+// raised by the heat players left there, up to 10. Callers must hold stateLock.
+func (gm *GameManager) subZoneRisk(zoneID string, subZone *types.SubZone) int {
+	risk := subZone.RiskLevel + gm.peekSubZoneState(zoneID, subZone.ID).Heat/20
+	if risk > 10 {
+		risk = 10
+	}
+	return risk
+}
+
+// territoryBonus returns the reward multiplier bonus the player gets in a
+// subzone: the faction bonus scaled by how firmly their faction controls it.
+// Callers must hold stateLock.
+func (gm *GameManager) territoryBonus(phoneNumber, zoneID, subZoneID string) int {
+	bonus := gm.factionTerritoryBonus(phoneNumber, zoneID, subZoneID)
+	if bonus == 0 {
+		return 0
+	}
+	return bonus * gm.peekSubZoneState(zoneID, subZoneID).Control / 100
+}
+
+// applyPoliceYield scales down the money made in a subzone by the police
+// presence there: full police takes half of it. Losses are kept as they are.
+func applyPoliceYield(outcome *types.Outcome, state types.SubZoneState) {
+	if outcome.MoneyChange > 0 {
+		outcome.MoneyChange = outcome.MoneyChange * (200 - state.Police) / 200
+	}
+}
+
+// eventMatchesSubZone reports whether an event can happen in the subzone's
+// current state: events with min_heat or min_police only happen where things
+// are that hot
+func eventMatchesSubZone(event *types.Event, state types.SubZoneState) bool {
+	return state.Heat >= event.MinHeat && state.Police >= event.MinPolice
+}
+
+// lostTerritory is a subzone a faction lost control of
+type lostTerritory struct {
+	faction *types.Faction
+	zone    string
+	subZone string
+}
+
+// decayWorld cools every subzone down: heat drops, the police follows the
+// heat up or down, and claimed subzones lose control, so factions that stop
+// showing up in their territory eventually lose it
+func (gm *GameManager) decayWorld(now time.Time) []lostTerritory {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	var lost []lostTerritory
+	for key, state := range gm.state.World {
+		before := *state
+
+		state.Heat = clampPercent(state.Heat - gm.config.Game.HeatDecay)
+		state.Police = clampPercent(state.Police + policeStep(state.Heat-state.Police, gm.config.Game.PoliceResponse))
+
+		owner := gm.territoryOwner(state.Zone, state.SubZone)
+		if owner == nil {
+			state.Control = 0
+		} else {
+			state.Control = clampPercent(state.Control - gm.config.Game.ControlDecay)
+			if state.Control == 0 {
+				gm.dropTerritory(owner, state.Zone, state.SubZone)
+				lost = append(lost, lostTerritory{faction: owner, zone: state.Zone, subZone: state.SubZone})
+			}
+		}
+
+		if *state != before {
+			state.UpdatedAt = now
+			gm.markWorldDirty(key)
+		}
+	}
+
+	for _, l := range lost {
+		gm.notifyFaction(l.faction, "", fmt.Sprintf("🏳️ A facção *%s* perdeu o controle de *%s*. Ninguém apareceu por lá! 😤",
+			l.faction.Name, gm.subZoneDisplayName(l.zone, l.subZone)))
+	}
+
+	return lost
+}
+
+// policeStep returns how much the police moves toward the heat: the given
+// percentage of the gap, and at least one point while there is a gap
+func policeStep(gap, percent int) int {
+	step := gap * percent / 100
+	if step == 0 && percent > 0 {
+		if gap > 0 {
+			step = 1
+		} else if gap < 0 {
+			step = -1
+		}
+	}
+	return step
+}
+
+// clampPercent keeps a value between 0 and 100
+func clampPercent(value int) int {
+	if value < 0 {
+		return 0
+	}
+	if value > 100 {
+		return 100
+	}
+	return value
+}
+
+// WorldSystem periodically decays the dynamic state of the subzones
+type WorldSystem struct {
+	gameManager *GameManager
+	ticker      *time.Ticker
+	stopChan    chan struct{}
+	doneChan    chan struct{}
+	logger      *zap.Logger
+}
+
+// NewWorldSystem creates a new world system
+func NewWorldSystem(gameManager *GameManager, decayInterval time.Duration, logger *zap.Logger) *WorldSystem {
+	return &WorldSystem{
+		gameManager: gameManager,
+		ticker:      time.NewTicker(decayInterval),
+		stopChan:    make(chan struct{}),
+		doneChan:    make(chan struct{}),
+		logger:      logger,
+	}
+}
+
+// Start begins the world system
+func (ws *WorldSystem) Start() {
+	go func() {
+		defer close(ws.doneChan)
+
+		for {
+			select {
+			case <-ws.ticker.C:
+				ws.decay()
+			case <-ws.stopChan:
+				ws.ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the world system, waiting for a running decay to finish
+func (ws *WorldSystem) Stop() {
+	close(ws.stopChan)
+	<-ws.doneChan
+}
+
+// decay cools the subzones down and logs the territories factions lost
+func (ws *WorldSystem) decay() {
+	for _, l := range ws.gameManager.decayWorld(time.Now()) {
+		ws.logger.Info("Faction lost territory",
+			zap.String("faction_id", l.faction.ID),
+			zap.String("zone", l.zone),
+			zap.String("sub_zone", l.subZone))
+	}
+}
@@ -0,0 +1,457 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/user/vida-loka-strategy/internal/types"
+	"go.uber.org/zap"
+)
+
+// worldEventType marks the events players get from a world event; they are
+// never drawn as random events
+const worldEventType = "world"
+
+// worldEventPrefix starts the event ID, and so the decision ID, of the event
+// players get from a world event: "world_<id>"
+const worldEventPrefix = "world_"
+
+// worldEventRun is a world event going on
+type worldEventRun struct {
+	event     *types.WorldEvent
+	startedAt time.Time
+	endsAt    time.Time
+
+	// Everyone in the area when it started, who gets the summary at the end
+	affected []*worldEventParticipant
+}
+
+// worldEventParticipant is a player hit by a world event and what they did
+type worldEventParticipant struct {
+	phoneNumber string
+	name        string
+	answered    bool
+	optionID    string
+	success     bool
+	outcome     types.Outcome
+}
+
+// LoadWorldEvents loads the world event templates. World events going on
+// live only in memory, so a world event a player still holds from before a
+// restart has no run anymore and is cleared.
+func (gm *GameManager) LoadWorldEvents(events []*types.WorldEvent) {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	for _, event := range events {
+		gm.worldEvents[event.ID] = event
+	}
+
+	cleared := 0
+	for _, player := range gm.state.Players {
+		if player.CurrentEvent == nil || player.CurrentEvent.Type != worldEventType {
+			continue
+		}
+		if _, running := gm.activeWorldEvents[strings.TrimPrefix(player.CurrentEvent.ID, worldEventPrefix)]; running {
+			continue
+		}
+
+		player.CurrentEvent = nil
+
+		// Queue the player for the next save
+		gm.markDirty(player)
+		cleared++
+	}
+
+	if cleared > 0 {
+		gm.Logger.Info("Cleared world events left over from before the restart",
+			zap.Int("players", cleared))
+	}
+}
+
+// worldEventCovers reports whether a subzone is in a world event's area
+func worldEventCovers(event *types.WorldEvent, zoneID, subZoneID string) bool {
+	return event.Zone == zoneID && (event.SubZone == "" || event.SubZone == subZoneID)
+}
+
+// worldEventsOverlap reports whether two world events share any subzone
+func worldEventsOverlap(a, b *types.WorldEvent) bool {
+	return a.Zone == b.Zone && (a.SubZone == "" || b.SubZone == "" || a.SubZone == b.SubZone)
+}
+
+// worldEventAt returns the world event going on in a subzone, or nil.
+// Callers must hold stateLock.
+func (gm *GameManager) worldEventAt(zoneID, subZoneID string) *worldEventRun {
+	for _, run := range gm.activeWorldEvents {
+		if worldEventCovers(run.event, zoneID, subZoneID) {
+			return run
+		}
+	}
+	return nil
+}
+
+// applyWorldEventModifiers scales what an action yields in the area of a
+// world event and adds its extra stress
+func applyWorldEventModifiers(outcome *types.Outcome, modifiers types.WorldEventModifiers) {
+	if modifiers.YieldPercent > 0 {
+		scale := func(value int) int {
+			if value <= 0 {
+				return value
+			}
+			return value * modifiers.YieldPercent / 100
+		}
+		outcome.XPChange = scale(outcome.XPChange)
+		outcome.MoneyChange = scale(outcome.MoneyChange)
+		outcome.InfluenceChange = scale(outcome.InfluenceChange)
+	}
+	outcome.StressChange += modifiers.StressChange
+}
+
+// startWorldEvent rolls for a new world event in an area that doesn't have
+// one yet. It heats up the area and gives everyone there who is free to
+// answer the event's options; the others are only warned. It returns the
+// messages for the players.
+func (gm *GameManager) startWorldEvent(now time.Time) []playerNotice {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	// The dice roller belongs to the event system's goroutine, so roll with
+	// the goroutine-safe package generator
+	if rand.Intn(100) >= gm.config.Game.WorldEventChance {
+		return nil
+	}
+
+	var candidates []*types.WorldEvent
+	for _, template := range gm.worldEvents {
+		free := true
+		for _, run := range gm.activeWorldEvents {
+			if worldEventsOverlap(template, run.event) {
+				free = false
+				break
+			}
+		}
+		if free {
+			candidates = append(candidates, template)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	// Map order is random, so sort before drawing to keep the draw fair
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].ID < candidates[j].ID
+	})
+	template := candidates[rand.Intn(len(candidates))]
+
+	zone, exists := gm.state.Zones[template.Zone]
+	if !exists {
+		gm.Logger.Warn("Zone of world event not found",
+			zap.String("world_event_id", template.ID),
+			zap.String("zone", template.Zone))
+		return nil
+	}
+
+	run := &worldEventRun{
+		event:     template,
+		startedAt: now,
+		endsAt:    now.Add(time.Duration(template.Duration) * time.Minute),
+	}
+
+	// The whole area heats up and draws the police
+	for _, subZone := range zone.SubZones {
+		if !worldEventCovers(template, zone.ID, subZone.ID) {
+			continue
+		}
+		state := gm.subZoneState(zone.ID, subZone.ID)
+		state.Heat = clampPercent(state.Heat + template.Modifiers.Heat)
+		state.Police = clampPercent(state.Police + template.Modifiers.Police)
+		state.UpdatedAt = now
+
+		// Queue the subzone for the next save
+		gm.markWorldDirty(subZoneKey(zone.ID, subZone.ID))
+	}
+
+	event := types.Event{
+		ID:          worldEventPrefix + template.ID,
+		Title:       template.Title,
+		Description: template.Description,
+		Options:     template.Options,
+		Type:        worldEventType,
+	}
+
+	var notices []playerNotice
+	for _, player := range gm.state.Players {
+		if player.CurrentCharacter == nil || player.Travel != nil ||
+			!worldEventCovers(template, player.CurrentZone, player.CurrentSubZone) {
+			continue
+		}
+		if player.Status != "active" && player.Status != "autopilot" {
+			continue
+		}
+
+		run.affected = append(run.affected, &worldEventParticipant{phoneNumber: player.PhoneNumber, name: player.Name})
+
+		// Players busy with another event, resting or not taking events
+		// still live through it, they just don't get to choose
+		if player.CurrentEvent != nil || inQuietHours(player.Notifications, now) || isMuted(player.Notifications, categoryEvents) {
+			notices = append(notices, playerNotice{
+				phoneNumber: player.PhoneNumber,
+				category:    categoryNews,
+				message:     formatWorldEventAlert(run),
+			})
+			continue
+		}
+
+		eventCopy := event
+		player.CurrentEvent = &eventCopy
+		player.LastEventAt = now
+
+		// Queue the player for the next save
+		gm.markDirty(player)
+
+		// The auto-pilot answers for players who left it on
+		if player.Status == "autopilot" {
+			continue
+		}
+
+		notices = append(notices, playerNotice{
+			phoneNumber: player.PhoneNumber,
+			category:    categoryEvents,
+			message:     formatWorldEventMessage(player, run),
+		})
+	}
+
+	gm.activeWorldEvents[template.ID] = run
+
+	gm.Logger.Info("World event started",
+		zap.String("world_event_id", template.ID),
+		zap.String("zone", template.Zone),
+		zap.String("sub_zone", template.SubZone),
+		zap.Int("affected", len(run.affected)))
+
+	return notices
+}
+
+// recordWorldEventChoice keeps what a player did in a world event for its
+// summary. Callers must hold stateLock.
+func (gm *GameManager) recordWorldEventChoice(player *types.Player, event *types.Event, optionID string, success bool, outcome types.Outcome) {
+	if event.Type != worldEventType {
+		return
+	}
+
+	run, exists := gm.activeWorldEvents[strings.TrimPrefix(event.ID, worldEventPrefix)]
+	if !exists {
+		return
+	}
+
+	for _, participant := range run.affected {
+		if participant.phoneNumber == player.PhoneNumber {
+			participant.answered = true
+			participant.optionID = optionID
+			participant.success = success
+			participant.outcome = outcome
+			return
+		}
+	}
+}
+
+// finishWorldEvents ends the world events whose time is up. Unanswered
+// options expire, and everyone who was hit gets the summary of how the area
+// got through it.
+func (gm *GameManager) finishWorldEvents(now time.Time) []playerNotice {
+	gm.stateLock.Lock()
+	defer gm.stateLock.Unlock()
+
+	var notices []playerNotice
+	for id, run := range gm.activeWorldEvents {
+		if now.Before(run.endsAt) {
+			continue
+		}
+
+		eventID := worldEventPrefix + run.event.ID
+		summary := formatWorldEventSummary(run)
+
+		for _, participant := range run.affected {
+			if player, exists := gm.state.Players[participant.phoneNumber]; exists &&
+				player.CurrentEvent != nil && player.CurrentEvent.ID == eventID {
+				player.CurrentEvent = nil
+
+				// Queue the player for the next save
+				gm.markDirty(player)
+			}
+
+			notices = append(notices, playerNotice{
+				phoneNumber: participant.phoneNumber,
+				category:    categoryNews,
+				message:     summary,
+			})
+		}
+
+		delete(gm.activeWorldEvents, id)
+
+		gm.Logger.Info("World event ended",
+			zap.String("world_event_id", run.event.ID),
+			zap.Int("affected", len(run.affected)))
+	}
+
+	return notices
+}
+
+// formatWorldEventMessage formats a world event for a player who gets to
+// choose, with their own attribute next to each option
+func formatWorldEventMessage(player *types.Player, run *worldEventRun) string {
+	message := fmt.Sprintf("🚨 *%s* 🚨\n\n%s\n\n", strings.ToUpper(run.event.Title), run.event.Description)
+	message += fmt.Sprintf("Isso vale pra todo mundo na área até as %s. E aí, %s, o que você faz?\n",
+		run.endsAt.Format("15:04"), player.Name)
+
+	commands := make([]string, len(run.event.Options))
+	for i, option := range run.event.Options {
+		message += fmt.Sprintf("%s. %s (%s: %d)\n", string(rune('A'+i)), option.Description,
+			option.RequiredAttribute, playerAttribute(player, option.RequiredAttribute))
+		commands[i] = fmt.Sprintf("*/%s*", string(rune('a'+i)))
+	}
+
+	if len(commands) > 0 {
+		answer := commands[0]
+		if len(commands) > 1 {
+			answer = strings.Join(commands[:len(commands)-1], ", ") + " ou " + commands[len(commands)-1]
+		}
+		message += fmt.Sprintf("\nResponda com %s antes que acabe! 🎲", answer)
+	}
+
+	return message
+}
+
+// formatWorldEventAlert warns a player caught in a world event without
+// options to choose
+func formatWorldEventAlert(run *worldEventRun) string {
+	return fmt.Sprintf("🚨 *%s* 🚨\n\n%s\n\nVocê tá na área e vai sentir os efeitos até as %s. Fica ligado!",
+		strings.ToUpper(run.event.Title), run.event.Description, run.endsAt.Format("15:04"))
+}
+
+// formatWorldEventSummary tells how everyone hit by a world event got
+// through it: how many chose each option and how it went, what the area
+// gained or lost, and who came out best
+func formatWorldEventSummary(run *worldEventRun) string {
+	message := fmt.Sprintf("📰 *FIM: %s* 📰\n\n", strings.ToUpper(run.event.Title))
+
+	answered := 0
+	var total types.Outcome
+	var best *worldEventParticipant
+	for _, participant := range run.affected {
+		if !participant.answered {
+			continue
+		}
+		answered++
+		total.XPChange += participant.outcome.XPChange
+		total.MoneyChange += participant.outcome.MoneyChange
+		total.InfluenceChange += participant.outcome.InfluenceChange
+		total.StressChange += participant.outcome.StressChange
+		if best == nil || participant.outcome.MoneyChange+participant.outcome.XPChange > best.outcome.MoneyChange+best.outcome.XPChange {
+			best = participant
+		}
+	}
+
+	message += fmt.Sprintf("*%d* pessoa(s) estavam na área e *%d* tomaram uma atitude.\n", len(run.affected), answered)
+
+	for i, option := range run.event.Options {
+		chose, succeeded := 0, 0
+		for _, participant := range run.affected {
+			if participant.answered && participant.optionID == option.ID {
+				chose++
+				if participant.success {
+					succeeded++
+				}
+			}
+		}
+		message += fmt.Sprintf("\n%s. %s: %d (%d deram certo)", string(rune('A'+i)), option.Description, chose, succeeded)
+	}
+
+	if missed := len(run.affected) - answered; missed > 0 {
+		message += fmt.Sprintf("\nNão responderam: %d", missed)
+	}
+
+	if answered > 0 {
+		message += "\n\n*Saldo da galera*:" + formatOutcomeChanges(&total)
+	}
+	if best != nil {
+		message += fmt.Sprintf("\n\n🏆 Quem se deu melhor: *%s*", best.name)
+	}
+
+	return message
+}
+
+// WorldEventSystem starts world events now and then and ends them when their
+// time is up
+type WorldEventSystem struct {
+	gameManager  *GameManager
+	ticker       *time.Ticker
+	rollInterval time.Duration
+	nextRoll     time.Time
+	stopChan     chan struct{}
+	doneChan     chan struct{}
+	logger       *zap.Logger
+}
+
+// NewWorldEventSystem creates a new world event system that checks for
+// events ending every checkInterval and rolls for a new one every
+// rollInterval
+func NewWorldEventSystem(gameManager *GameManager, checkInterval, rollInterval time.Duration, logger *zap.Logger) *WorldEventSystem {
+	return &WorldEventSystem{
+		gameManager:  gameManager,
+		ticker:       time.NewTicker(checkInterval),
+		rollInterval: rollInterval,
+		nextRoll:     time.Now().Add(rollInterval),
+		stopChan:     make(chan struct{}),
+		doneChan:     make(chan struct{}),
+		logger:       logger,
+	}
+}
+
+// Start begins the world event system
+func (ws *WorldEventSystem) Start() {
+	go func() {
+		defer close(ws.doneChan)
+
+		for {
+			select {
+			case now := <-ws.ticker.C:
+				ws.tick(now)
+			case <-ws.stopChan:
+				ws.ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the world event system, waiting for a running check to finish.
+// World events going on live only in memory and are lost on a restart, see
+// LoadWorldEvents.
+func (ws *WorldEventSystem) Stop() {
+	close(ws.stopChan)
+	<-ws.doneChan
+}
+
+// tick ends the world events that are over and, when it is time, rolls for a
+// new one, then messages the players
+func (ws *WorldEventSystem) tick(now time.Time) {
+	notices := ws.gameManager.finishWorldEvents(now)
+
+	if !now.Before(ws.nextRoll) {
+		ws.nextRoll = now.Add(ws.rollInterval)
+		notices = append(notices, ws.gameManager.startWorldEvent(now)...)
+	}
+
+	for _, notice := range notices {
+		if err := ws.gameManager.sendNotification(notice.phoneNumber, notice.category, notice.message); err != nil {
+			ws.logger.Error("Failed to send world event message",
+				zap.String("phone_number", notice.phoneNumber),
+				zap.Error(err))
+		}
+	}
+}
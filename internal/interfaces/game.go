@@ -1,6 +1,15 @@
 package interfaces
 
-import "github.com/user/vida-loka-strategy/internal/types"
+import (
+	"errors"
+
+	"github.com/user/vida-loka-strategy/internal/types"
+)
+
+// ErrNoPendingInteraction is returned by RespondToInteraction when no invite
+// is waiting for the player, or it has expired. It lives here so the
+// WhatsApp layer can check for it without importing the game package.
+var ErrNoPendingInteraction = errors.New("nenhum convite pendente")
 
 // MessageSender defines the interface for sending messages
 type MessageSender interface {
@@ -16,11 +25,40 @@ type GameManager interface {
 	GenerateEvent(phoneNumber string) (*types.Event, error)
 	ProcessEventChoice(phoneNumber, eventID, optionID string) (*types.Outcome, error)
 	GetPlayerStatus(phoneNumber string) (map[string]interface{}, error)
-	MovePlayer(playerID, zoneID, subZoneID string) error
 	GetAvailableCharacters() []*types.Character
+	GetActions() []*types.Action
 	GetAvailableActions(phoneNumber string) ([]*types.Action, error)
 	GetZone(zoneID string) (*types.Zone, error)
+	GetZones() []*types.Zone
+	FindLocation(query string) (*types.Zone, *types.SubZone, error)
+	QuoteTravel(phoneNumber, zoneID, subZoneID string) (*types.Travel, error)
+	ConfirmTravel(phoneNumber string) (*types.Travel, error)
+	SpendAttributePoints(phoneNumber, attribute string, points int) (*types.Stats, error)
+	GetEvolutionProgress(phoneNumber string) ([]types.EvolutionProgress, error)
+	StartInteraction(phoneNumber, kind, target string, amount int) (*types.Interaction, error)
+	RespondToInteraction(phoneNumber string, accept bool) (*types.Interaction, error)
+	CreateFaction(phoneNumber, name string) (*types.Faction, error)
+	InviteToFaction(phoneNumber, target string) (*types.Faction, error)
+	JoinFaction(phoneNumber, name string) (*types.Faction, error)
+	LeaveFaction(phoneNumber string) (*types.Faction, error)
+	KickFromFaction(phoneNumber, target string) (*types.Faction, error)
+	ContributeToFaction(phoneNumber string, amount int) (*types.Faction, error)
+	ClaimTerritory(phoneNumber string) (*types.Faction, error)
+	GetPlayerFaction(phoneNumber string) (*types.Faction, error)
+	GetSubZoneState(zoneID, subZoneID string) types.SubZoneState
+	GetMissions() []*types.Mission
+	GetGroupMission(groupJID string) (*types.GroupMission, error)
+	StartGroupMission(groupJID, phoneNumber, query string) (*types.GroupMission, error)
+	JoinGroupMission(groupJID, phoneNumber string) (*types.GroupMission, error)
+	LaunchGroupMission(groupJID, phoneNumber string) (*types.GroupMission, error)
+	VoteInGroupMission(groupJID, phoneNumber, choice string) (*types.EventOption, error)
+	BetrayGroupMission(groupJID, phoneNumber string) (*types.Outcome, error)
+	GetNotificationSettings(phoneNumber string) (*types.NotificationSettings, error)
+	SetQuietHours(phoneNumber string, hours *types.QuietHours) error
+	SetNotificationFrequency(phoneNumber, frequency string) error
+	SetNotificationCategory(phoneNumber, category string, enabled bool) error
 	GetAllPlayers() []*types.Player
 	TriggerRandomEvent(playerID string) (*types.Event, error)
 	SendMessage(playerID string, message string) error
+	SetPlayerStatus(phoneNumber, status string) error
 }
@@ -4,31 +4,115 @@ import "time"
 
 // GameState represents the overall state of the game
 type GameState struct {
-	Players    map[string]*Player    `json:"players"`
-	Characters map[string]*Character `json:"characters"`
-	Events     map[string]*Event     `json:"events"`
-	Actions    map[string]*Action    `json:"actions"`
-	Zones      map[string]*Zone      `json:"zones"`
+	Players    map[string]*Player       `json:"players"`
+	Characters map[string]*Character    `json:"characters"`
+	Events     map[string]*Event        `json:"events"`
+	Actions    map[string]*Action       `json:"actions"`
+	Zones      map[string]*Zone         `json:"zones"`
+	Evolutions map[string]*Evolution    `json:"evolutions"`
+	Factions   map[string]*Faction      `json:"factions"`
+	World      map[string]*SubZoneState `json:"world"`
 }
 
 // Player represents a game player
 type Player struct {
-	ID               string     `json:"id"`
-	PhoneNumber      string     `json:"phone_number"`
-	Name             string     `json:"name"`
-	CreatedAt        time.Time  `json:"created_at"`
-	LastActiveAt     time.Time  `json:"last_active_at"`
-	XP               int        `json:"xp"`
-	Money            int        `json:"money"`
-	Influence        int        `json:"influence"`
-	Status           string     `json:"status"`
-	Stress           int        `json:"stress"`
-	CurrentCharacter *Character `json:"current_character"`
-	CurrentZone      string     `json:"current_zone"`
-	CurrentSubZone   string     `json:"current_sub_zone"`
-	LastEventAt      time.Time  `json:"last_event_at"`
-	CurrentEvent     *Event     `json:"current_event"`
-	DecisionHistory  []Decision `json:"decision_history"`
+	ID               string                `json:"id"`
+	PhoneNumber      string                `json:"phone_number"`
+	Name             string                `json:"name"`
+	CreatedAt        time.Time             `json:"created_at"`
+	LastActiveAt     time.Time             `json:"last_active_at"`
+	XP               int                   `json:"xp"`
+	Money            int                   `json:"money"`
+	Influence        int                   `json:"influence"`
+	Status           string                `json:"status"`
+	Stress           int                   `json:"stress"`
+	CurrentCharacter *Character            `json:"current_character"`
+	CurrentZone      string                `json:"current_zone"`
+	CurrentSubZone   string                `json:"current_sub_zone"`
+	LastEventAt      time.Time             `json:"last_event_at"`
+	NextEventAt      time.Time             `json:"next_event_at,omitempty"`
+	CurrentEvent     *Event                `json:"current_event"`
+	DecisionHistory  []Decision            `json:"decision_history"`
+	Travel           *Travel               `json:"travel,omitempty"`
+	TravelQuote      *Travel               `json:"travel_quote,omitempty"`
+	Burnout          bool                  `json:"burnout"`
+	Stats            *Stats                `json:"stats,omitempty"`
+	Arc              *EventArc             `json:"arc,omitempty"`
+	CompletedArcs    []EventArc            `json:"completed_arcs,omitempty"`
+	Notifications    *NotificationSettings `json:"notifications,omitempty"`
+	Seasons          []string              `json:"seasons,omitempty"`
+}
+
+// NotificationSettings are a player's choices about the messages the game
+// sends on its own. Messages that come during quiet hours are kept in
+// Deferred and delivered together once the quiet hours are over.
+type NotificationSettings struct {
+	QuietHours *QuietHours       `json:"quiet_hours,omitempty"`
+	Frequency  string            `json:"frequency,omitempty"`
+	Muted      []string          `json:"muted,omitempty"`
+	Deferred   []DeferredMessage `json:"deferred,omitempty"`
+}
+
+// QuietHours is a daily window, from the start hour up to (not including) the
+// end hour. A start after the end wraps past midnight, e.g. 22 to 7.
+type QuietHours struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// DeferredMessage is a message held back during a player's quiet hours
+type DeferredMessage struct {
+	Category string    `json:"category,omitempty"`
+	Message  string    `json:"message"`
+	QueuedAt time.Time `json:"queued_at"`
+}
+
+// Stats holds a player's own level and attributes. They start as a copy of the
+// chosen character's attributes and grow with the player, leaving the shared
+// catalog character untouched.
+type Stats struct {
+	Level           int `json:"level"`
+	AttributePoints int `json:"attribute_points"`
+	Carisma         int `json:"carisma"`
+	Proficiencia    int `json:"proficiencia"`
+	Rede            int `json:"rede"`
+	Moralidade      int `json:"moralidade"`
+	Resiliencia     int `json:"resiliencia"`
+}
+
+// Travel represents a trip between two subzones. While a player has one in
+// progress they are in transit and can't act.
+type Travel struct {
+	FromZone        string    `json:"from_zone"`
+	FromSubZone     string    `json:"from_sub_zone"`
+	ToZone          string    `json:"to_zone"`
+	ToSubZone       string    `json:"to_sub_zone"`
+	Cost            int       `json:"cost"`
+	StressCost      int       `json:"stress_cost"`
+	DurationMinutes int       `json:"duration_minutes"` // game minutes
+	RiskLevel       int       `json:"risk_level"`
+	QuotedAt        time.Time `json:"quoted_at"`
+	StartedAt       time.Time `json:"started_at"`
+	ArrivesAt       time.Time `json:"arrives_at"`
+}
+
+// EventArc is a player's way through a chain of events linked by
+// next_event_id. Each answer can branch the arc to a different follow-up, and
+// the arc ends with the first outcome that has none.
+type EventArc struct {
+	StartEventID string    `json:"start_event_id"`
+	Path         []ArcStep `json:"path"`
+	EventID      string    `json:"event_id,omitempty"`
+	DueAt        time.Time `json:"due_at,omitempty"`
+	StartedAt    time.Time `json:"started_at"`
+	EndedAt      time.Time `json:"ended_at,omitempty"`
+}
+
+// ArcStep is an event of an arc and how the player answered it
+type ArcStep struct {
+	EventID  string `json:"event_id"`
+	OptionID string `json:"option_id"`
+	Success  bool   `json:"success"`
 }
 
 // Character represents a playable character
@@ -49,16 +133,196 @@ type Character struct {
 	EvolutionPaths   []string  `json:"evolution_paths"`
 }
 
+// Evolution is a character a player can turn into once they meet its
+// requirements. Its character fields are the new attribute profile.
+type Evolution struct {
+	Character
+	Requirements EvolutionRequirements `json:"requirements"`
+	Narrative    string                `json:"narrative"`
+}
+
+// EvolutionRequirements lists what a player needs to reach an evolution
+type EvolutionRequirements struct {
+	MinLevel         int      `json:"min_level"`
+	MinMoney         int      `json:"min_money"`
+	MinInfluence     int      `json:"min_influence"`
+	MinMoralidade    int      `json:"min_moralidade"`
+	MaxMoralidade    int      `json:"max_moralidade"`
+	RequiredChoices  []string `json:"required_choices"`
+	ForbiddenChoices []string `json:"forbidden_choices"`
+}
+
+// EvolutionProgress is how close a player is to an evolution
+type EvolutionProgress struct {
+	Evolution    *Evolution            `json:"evolution"`
+	Requirements []RequirementProgress `json:"requirements"`
+	Ready        bool                  `json:"ready"`
+}
+
+// RequirementProgress is a single requirement and whether it is met
+type RequirementProgress struct {
+	Description string `json:"description"`
+	Met         bool   `json:"met"`
+}
+
 // Event represents a game event
 type Event struct {
 	ID           string        `json:"id"`
 	Name         string        `json:"name"`
+	Title        string        `json:"title"`
 	Description  string        `json:"description"`
 	MinXP        int           `json:"min_xp"`
 	MinMoney     int           `json:"min_money"`
 	MinInfluence int           `json:"min_influence"`
 	RequiredZone []string      `json:"required_zone"`
 	Options      []EventOption `json:"options"`
+	Type         string        `json:"type"`
+	MinRisk      int           `json:"min_risk,omitempty"`
+	StressBand   string        `json:"stress_band,omitempty"`
+	MinHeat      int           `json:"min_heat,omitempty"`
+	MinPolice    int           `json:"min_police,omitempty"`
+	Encounter    *Encounter    `json:"encounter,omitempty"`
+	Conditions   *Conditions   `json:"conditions,omitempty"`
+}
+
+// Conditions narrow down who an event can happen to and when. Every
+// condition that is set must hold; unset ones are ignored.
+type Conditions struct {
+	SubZones         []string              `json:"sub_zones,omitempty"`
+	CharacterTypes   []string              `json:"character_types,omitempty"`
+	Attributes       map[string]ValueRange `json:"attributes,omitempty"`
+	Stress           *ValueRange           `json:"stress,omitempty"`
+	Hours            *ValueRange           `json:"hours,omitempty"`
+	Periods          []string              `json:"periods,omitempty"`
+	Days             string                `json:"days,omitempty"`
+	RequiredChoices  []string              `json:"required_choices,omitempty"`
+	ForbiddenChoices []string              `json:"forbidden_choices,omitempty"`
+	RequiredEvents   []string              `json:"required_events,omitempty"`
+	ForbiddenEvents  []string              `json:"forbidden_events,omitempty"`
+	Cooldown         int                   `json:"cooldown,omitempty"`
+	MaxOccurrences   int                   `json:"max_occurrences,omitempty"`
+}
+
+// ValueRange is an inclusive range of values; a missing bound is open
+type ValueRange struct {
+	Min *int `json:"min,omitempty"`
+	Max *int `json:"max,omitempty"`
+}
+
+// Encounter is the predator a player ran into in an encounter event. Its
+// options are contested rolls against the predator's attributes.
+type Encounter struct {
+	PredatorID    string `json:"predator_id"`
+	PredatorName  string `json:"predator_name"`
+	PredatorPhone string `json:"predator_phone,omitempty"`
+	PredatorStats Stats  `json:"predator_stats"`
+}
+
+// Interaction is a player-vs-player interaction: a challenge, robbery, help
+// or payment. Interactions that need the other player's consent wait for it
+// without a Result.
+type Interaction struct {
+	ID        string             `json:"id"`
+	Kind      string             `json:"kind"`
+	FromPhone string             `json:"from_phone"`
+	FromName  string             `json:"from_name"`
+	ToPhone   string             `json:"to_phone"`
+	ToName    string             `json:"to_name"`
+	Amount    int                `json:"amount,omitempty"`
+	CreatedAt time.Time          `json:"created_at"`
+	ExpiresAt time.Time          `json:"expires_at"`
+	Result    *InteractionResult `json:"result,omitempty"`
+}
+
+// Faction is a crew of players with a shared bank and the subzones it controls
+type Faction struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Members     []FactionMember `json:"members"`
+	Bank        int             `json:"bank"`
+	Territories []Territory     `json:"territories"`
+	Invites     []string        `json:"invites"`
+}
+
+// FactionMember is a player in a faction, with their role: "leader" or "member"
+type FactionMember struct {
+	PhoneNumber string    `json:"phone_number"`
+	Name        string    `json:"name"`
+	Role        string    `json:"role"`
+	JoinedAt    time.Time `json:"joined_at"`
+}
+
+// Territory is a subzone claimed by a faction
+type Territory struct {
+	Zone      string    `json:"zone"`
+	SubZone   string    `json:"sub_zone"`
+	ClaimedAt time.Time `json:"claimed_at"`
+}
+
+// SubZoneState is the dynamic state of a subzone, shaped by what players do
+// there: heat from crime, the police it draws and the grip of the faction
+// that owns it
+type SubZoneState struct {
+	Zone      string    `json:"zone"`
+	SubZone   string    `json:"sub_zone"`
+	Heat      int       `json:"heat"`
+	Police    int       `json:"police"`
+	Control   int       `json:"control"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Mission is a cooperative mission template played by several players in a
+// group chat. Each step is voted on by the group.
+type Mission struct {
+	ID          string        `json:"id"`
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	MinPlayers  int           `json:"min_players"`
+	MaxPlayers  int           `json:"max_players"`
+	Steps       []MissionStep `json:"steps"`
+}
+
+// MissionStep is a stage of a mission. The option the group votes for is
+// rolled by every participant who voted, against its difficulty_level per
+// participant.
+type MissionStep struct {
+	Description string        `json:"description"`
+	Options     []EventOption `json:"options"`
+}
+
+// GroupMission is a mission running in a group chat, bound to the group's JID
+type GroupMission struct {
+	ID           string               `json:"id"`
+	GroupJID     string               `json:"group_jid"`
+	Mission      *Mission             `json:"mission"`
+	Status       string               `json:"status"`
+	Step         int                  `json:"step"`
+	StartedBy    string               `json:"started_by"`
+	Participants []MissionParticipant `json:"participants"`
+	Pot          int                  `json:"pot"`
+	CreatedAt    time.Time            `json:"created_at"`
+	DeadlineAt   time.Time            `json:"deadline_at"`
+}
+
+// MissionParticipant is a player in a group mission, with their vote on the
+// current step and everything the mission did to them so far
+type MissionParticipant struct {
+	PhoneNumber string  `json:"phone_number"`
+	Name        string  `json:"name"`
+	Vote        string  `json:"vote,omitempty"`
+	Betrayed    bool    `json:"betrayed,omitempty"`
+	Outcome     Outcome `json:"outcome"`
+}
+
+// InteractionResult is the opposed roll of an interaction and what it did to
+// each side
+type InteractionResult struct {
+	FromRoll    int     `json:"from_roll"`
+	ToRoll      int     `json:"to_roll"`
+	Success     bool    `json:"success"`
+	FromOutcome Outcome `json:"from_outcome"`
+	ToOutcome   Outcome `json:"to_outcome"`
 }
 
 // EventOption represents an option in an event
@@ -78,18 +342,32 @@ type Outcome struct {
 	MoneyChange     int    `json:"money_change"`
 	InfluenceChange int    `json:"influence_change"`
 	StressChange    int    `json:"stress_change"`
+	HeatChange      int    `json:"heat_change,omitempty"`
 	NewZone         string `json:"new_zone,omitempty"`
 	NewSubZone      string `json:"new_sub_zone,omitempty"`
 	NextEventID     string `json:"next_event_id,omitempty"`
+	NextEventDelay  int    `json:"next_event_delay,omitempty"`
 }
 
 // Action represents a game action
 type Action struct {
-	ID             string  `json:"id"`
-	Name           string  `json:"name"`
-	Description    string  `json:"description"`
-	BonusAttribute string  `json:"bonus_attribute"`
-	BaseOutcome    Outcome `json:"base_outcome"`
+	ID             string         `json:"id"`
+	Name           string         `json:"name"`
+	Description    string         `json:"description"`
+	BonusAttribute string         `json:"bonus_attribute"`
+	BaseOutcome    Outcome        `json:"base_outcome"`
+	TimeModifiers  []TimeModifier `json:"time_modifiers,omitempty"`
+}
+
+// TimeModifier changes what an action yields at some times of the game clock,
+// optionally only in some subzones. Every field that is set must hold.
+type TimeModifier struct {
+	Periods      []string `json:"periods,omitempty"`
+	Days         string   `json:"days,omitempty"`
+	SubZones     []string `json:"sub_zones,omitempty"`
+	YieldPercent int      `json:"yield_percent,omitempty"`
+	StressChange int      `json:"stress_change,omitempty"`
+	Description  string   `json:"description,omitempty"`
 }
 
 // Zone represents a game zone
@@ -111,6 +389,62 @@ type SubZone struct {
 	RiskLevel        int      `json:"risk_level"`
 	RewardMultiplier int      `json:"reward_multiplier"`
 	AvailableActions []string `json:"available_actions"`
+	ArrivalMessages  []string `json:"arrival_messages"`
+}
+
+// Season is a stretch of the calendar that changes the city, such as
+// Carnaval or Réveillon. Its window is either start and end dates, as "MM-DD"
+// every year or "YYYY-MM-DD" once, or day offsets from a moving holiday set in
+// anchor ("pascoa"). Both ends are inclusive. While it lasts, its events are
+// drawn more often, its temporary subzones are open and actions in its zones
+// pay more.
+type Season struct {
+	ID                string            `json:"id"`
+	Name              string            `json:"name"`
+	Announcement      string            `json:"announcement"`
+	Start             string            `json:"start,omitempty"`
+	End               string            `json:"end,omitempty"`
+	Anchor            string            `json:"anchor,omitempty"`
+	StartOffset       int               `json:"start_offset,omitempty"`
+	EndOffset         int               `json:"end_offset,omitempty"`
+	Zones             []string          `json:"zones,omitempty"`
+	SubZones          []string          `json:"sub_zones,omitempty"`
+	RewardMultiplier  int               `json:"reward_multiplier,omitempty"`
+	EventWeight       int               `json:"event_weight,omitempty"`
+	Events            []string          `json:"events,omitempty"`
+	TemporarySubZones []SeasonalSubZone `json:"temporary_sub_zones,omitempty"`
+}
+
+// SeasonalSubZone is a subzone that only exists in a zone during a season
+type SeasonalSubZone struct {
+	Zone    string  `json:"zone"`
+	SubZone SubZone `json:"sub_zone"`
+}
+
+// WorldEvent is a city-wide event that hits every player in a zone, or in one
+// of its subzones, at once. For its duration the actions there yield and
+// stress differently and some may be shut down, and everyone there when it
+// starts gets its options.
+type WorldEvent struct {
+	ID          string              `json:"id"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Zone        string              `json:"zone"`
+	SubZone     string              `json:"sub_zone,omitempty"`
+	Duration    int                 `json:"duration"`
+	Modifiers   WorldEventModifiers `json:"modifiers"`
+	Options     []EventOption       `json:"options"`
+}
+
+// WorldEventModifiers are what a world event does to its area: the percentage
+// of the positive XP, money and influence actions yield, extra stress per
+// action, actions that can't be done, and heat and police added when it starts
+type WorldEventModifiers struct {
+	YieldPercent   int      `json:"yield_percent,omitempty"`
+	StressChange   int      `json:"stress_change,omitempty"`
+	BlockedActions []string `json:"blocked_actions,omitempty"`
+	Heat           int      `json:"heat,omitempty"`
+	Police         int      `json:"police,omitempty"`
 }
 
 // Decision represents a player's decision in the game
@@ -2,10 +2,12 @@ package whatsapp
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -34,13 +36,42 @@ type GameManager interface {
 	GenerateEvent(phoneNumber string) (*types.Event, error)
 	ProcessEventChoice(phoneNumber, eventID, optionID string) (*types.Outcome, error)
 	GetPlayerStatus(phoneNumber string) (map[string]interface{}, error)
-	MovePlayer(playerID, zoneID, subZoneID string) error
 	GetAvailableCharacters() []*types.Character
+	GetActions() []*types.Action
 	GetAvailableActions(phoneNumber string) ([]*types.Action, error)
 	GetZone(zoneID string) (*types.Zone, error)
+	GetZones() []*types.Zone
+	FindLocation(query string) (*types.Zone, *types.SubZone, error)
+	QuoteTravel(phoneNumber, zoneID, subZoneID string) (*types.Travel, error)
+	ConfirmTravel(phoneNumber string) (*types.Travel, error)
+	SpendAttributePoints(phoneNumber, attribute string, points int) (*types.Stats, error)
+	GetEvolutionProgress(phoneNumber string) ([]types.EvolutionProgress, error)
+	StartInteraction(phoneNumber, kind, target string, amount int) (*types.Interaction, error)
+	RespondToInteraction(phoneNumber string, accept bool) (*types.Interaction, error)
+	CreateFaction(phoneNumber, name string) (*types.Faction, error)
+	InviteToFaction(phoneNumber, target string) (*types.Faction, error)
+	JoinFaction(phoneNumber, name string) (*types.Faction, error)
+	LeaveFaction(phoneNumber string) (*types.Faction, error)
+	KickFromFaction(phoneNumber, target string) (*types.Faction, error)
+	ContributeToFaction(phoneNumber string, amount int) (*types.Faction, error)
+	ClaimTerritory(phoneNumber string) (*types.Faction, error)
+	GetPlayerFaction(phoneNumber string) (*types.Faction, error)
+	GetSubZoneState(zoneID, subZoneID string) types.SubZoneState
+	GetMissions() []*types.Mission
+	GetGroupMission(groupJID string) (*types.GroupMission, error)
+	StartGroupMission(groupJID, phoneNumber, query string) (*types.GroupMission, error)
+	JoinGroupMission(groupJID, phoneNumber string) (*types.GroupMission, error)
+	LaunchGroupMission(groupJID, phoneNumber string) (*types.GroupMission, error)
+	VoteInGroupMission(groupJID, phoneNumber, choice string) (*types.EventOption, error)
+	BetrayGroupMission(groupJID, phoneNumber string) (*types.Outcome, error)
+	GetNotificationSettings(phoneNumber string) (*types.NotificationSettings, error)
+	SetQuietHours(phoneNumber string, hours *types.QuietHours) error
+	SetNotificationFrequency(phoneNumber, frequency string) error
+	SetNotificationCategory(phoneNumber, category string, enabled bool) error
 	GetAllPlayers() []*types.Player
 	TriggerRandomEvent(playerID string) (*types.Event, error)
 	SendMessage(playerID string, message string) error
+	SetPlayerStatus(phoneNumber, status string) error
 }
 
 // ClientManager handles WhatsApp client connections
@@ -50,6 +81,12 @@ type ClientManager struct {
 	config      config.Config
 	logger      *zap.Logger
 	mutex       sync.RWMutex
+	commands    *CommandRegistry
+
+	// In-flight message handlers, drained on shutdown
+	handlers    sync.WaitGroup
+	handlerLock sync.Mutex
+	closing     bool
 }
 
 // ClientInfo holds information about a WhatsApp client connection
@@ -69,6 +106,9 @@ func NewClientManager(gameManager GameManager, cfg config.Config, logger *zap.Lo
 		logger:      logger,
 	}
 
+	// Build the command router
+	cm.registerCommands()
+
 	// Restore existing sessions
 	cm.restoreExistingSessions()
 
@@ -399,18 +439,21 @@ func (cm *ClientManager) SendTextMessage(phoneNumber, recipient, message string)
 		return "", fmt.Errorf("client not found for phone number: %s", phoneNumber)
 	}
 
-	// Format recipient phone number
-	// Remove any non-digit characters
-	recipient = strings.Map(func(r rune) rune {
-		if r >= '0' && r <= '9' {
-			return r
-		}
-		return -1
-	}, recipient)
+	// Format recipient phone number; full JIDs, like group chats, are
+	// used as they are
+	if !strings.ContainsRune(recipient, '@') {
+		// Remove any non-digit characters
+		recipient = strings.Map(func(r rune) rune {
+			if r >= '0' && r <= '9' {
+				return r
+			}
+			return -1
+		}, recipient)
 
-	// Add country code if not present
-	if !strings.HasPrefix(recipient, "55") {
-		recipient = "55" + recipient
+		// Add country code if not present
+		if !strings.HasPrefix(recipient, "55") {
+			recipient = "55" + recipient
+		}
 	}
 
 	// Parse recipient JID
@@ -433,10 +476,52 @@ func (cm *ClientManager) SendTextMessage(phoneNumber, recipient, message string)
 	return response.ID, nil
 }
 
+// beginHandler registers an in-flight message handler, refusing new ones once
+// shutdown has started
+func (cm *ClientManager) beginHandler() bool {
+	cm.handlerLock.Lock()
+	defer cm.handlerLock.Unlock()
+
+	if cm.closing {
+		return false
+	}
+
+	cm.handlers.Add(1)
+	return true
+}
+
+// Shutdown stops handling new messages and waits for the in-flight handlers to
+// finish, giving up when ctx is done
+func (cm *ClientManager) Shutdown(ctx context.Context) error {
+	cm.handlerLock.Lock()
+	cm.closing = true
+	cm.handlerLock.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		cm.handlers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("timed out waiting for message handlers: %w", ctx.Err())
+	}
+}
+
 // handleWhatsAppEvent processes incoming WhatsApp events
 func (cm *ClientManager) handleWhatsAppEvent(evt interface{}) {
 	switch v := evt.(type) {
 	case *events.Message:
+		if !cm.beginHandler() {
+			cm.logger.Info("Ignoring message received during shutdown",
+				zap.String("sender", v.Info.Sender.User))
+			return
+		}
+		defer cm.handlers.Done()
+
 		cm.handleIncomingMessage(v)
 	case *events.Connected:
 		cm.logger.Info("WhatsApp client connected")
@@ -469,17 +554,14 @@ func (cm *ClientManager) handleIncomingMessage(message *events.Message) {
 	isGroup := message.Info.Chat.Server == "g.us"
 
 	// For group messages, only process if it starts with '/ '
+	chat := ""
 	if isGroup {
 		if !strings.HasPrefix(content, "/ ") {
 			return
 		}
-		// Remove the '/ ' prefix for group messages
-		content = strings.TrimPrefix(content, "/ ")
-	} else {
-		// For private messages, check for '/' prefix
-		if !strings.HasPrefix(content, "/") {
-			return
-		}
+		// Turn '/ status' into the '/status' every command is parsed from
+		content = "/" + strings.TrimPrefix(strings.TrimPrefix(content, "/ "), "/")
+		chat = message.Info.Chat.String()
 	}
 
 	// Log message details
@@ -488,8 +570,19 @@ func (cm *ClientManager) handleIncomingMessage(message *events.Message) {
 		zap.String("sender", message.Info.Sender.User),
 		zap.String("chat", message.Info.Chat.User))
 
-	// Process command
-	response := cm.processGameCommand(message.Info.Sender.User, content)
+	// Any message from a player on auto-pilot hands control back to them
+	response := cm.resumeFromAutoPilot(message.Info.Sender.User, content)
+
+	// For private messages, only commands starting with '/' are processed
+	if isGroup || strings.HasPrefix(content, "/") {
+		if commandResponse := cm.processGameCommand(message.Info.Sender.User, chat, content); commandResponse != "" {
+			if response != "" {
+				response += "\n\n"
+			}
+			response += commandResponse
+		}
+	}
+
 	if response != "" {
 		// Get the first client from the manager (our bot's client)
 		cm.mutex.RLock()
@@ -525,8 +618,36 @@ func (cm *ClientManager) handleIncomingMessage(message *events.Message) {
 	}
 }
 
-// processGameCommand handles game commands from players
-func (cm *ClientManager) processGameCommand(sender, command string) string {
+// resumeFromAutoPilot switches a player on auto-pilot back to active play and
+// returns a notice for them, or an empty string if nothing changed. The
+// auto-pilot toggle command is left alone so it can do the switch itself.
+func (cm *ClientManager) resumeFromAutoPilot(sender, content string) string {
+	if strings.TrimPrefix(cleanCommand(content), "/") == "piloto" {
+		return ""
+	}
+
+	player, err := cm.gameManager.GetPlayer(sender)
+	if err != nil || player.Status != "autopilot" {
+		return ""
+	}
+
+	if err := cm.gameManager.SetPlayerStatus(sender, "active"); err != nil {
+		cm.logger.Error("Failed to resume player from auto-pilot",
+			zap.String("sender", sender),
+			zap.Error(err))
+		return ""
+	}
+
+	cm.logger.Info("Player resumed from auto-pilot",
+		zap.String("sender", sender))
+
+	return "🎮 *PILOTO AUTOMÁTICO DESLIGADO* 🎮\n\n" +
+		"Bem-vindo de volta! Agora é com você de novo."
+}
+
+// processGameCommand handles game commands from players, sent in the group
+// chat with the given JID or, when chat is empty, in private
+func (cm *ClientManager) processGameCommand(sender, chat, command string) string {
 	// Add logging for all incoming commands
 	cm.logger.Info("Processing game command",
 		zap.String("sender", sender),
@@ -549,73 +670,8 @@ func (cm *ClientManager) processGameCommand(sender, command string) string {
 	cm.logger.Debug("Processing cleaned command",
 		zap.String("cleaned_command", command))
 
-	// Check if this is a setup command (hidden from help)
-	if command == "setup" {
-		cm.logger.Info("Handling setup command",
-			zap.String("sender", sender))
-		return cm.handleSetupCommand(sender, command)
-	}
-
-	// Check if this is a help command
-	if command == "ajuda" || command == "help" {
-		cm.logger.Info("Handling help command")
-		return cm.handleHelpCommand()
-	}
-
-	// Check if this is a move command
-	if strings.HasPrefix(command, "mover") {
-		cm.logger.Info("Handling move command",
-			zap.String("command", command))
-		return cm.handleMoveCommand(sender, command)
-	}
-
-	// Check if this is a registration command
-	if strings.HasPrefix(command, "comecar") || strings.HasPrefix(command, "começar") || strings.HasPrefix(command, "iniciar") {
-		cm.logger.Info("Handling registration command",
-			zap.String("command", command))
-		return cm.handleRegistrationCommand(sender, command)
-	}
-
-	// Check if this is a character selection command
-	if strings.HasPrefix(command, "escolher") {
-		cm.logger.Info("Handling character selection command",
-			zap.String("command", command))
-		return cm.handleCharacterSelectionCommand(sender, command)
-	}
-
-	// Check if this is a status command
-	if command == "status" {
-		cm.logger.Info("Handling status command")
-		return cm.handleStatusCommand(sender)
-	}
-
-	// Check if this is a characters list command
-	if command == "personagens" {
-		cm.logger.Info("Handling characters list command")
-		return cm.handleCharactersListCommand()
-	}
-
-	// Check if this is an action command
-	if strings.HasPrefix(command, "trabalhar") ||
-		strings.HasPrefix(command, "estudar") ||
-		strings.HasPrefix(command, "relaxar") ||
-		strings.HasPrefix(command, "curtir") ||
-		strings.HasPrefix(command, "dormir") ||
-		strings.HasPrefix(command, "meditar") ||
-		strings.HasPrefix(command, "networking") ||
-		strings.HasPrefix(command, "treinar") ||
-		strings.HasPrefix(command, "empreender") ||
-		strings.HasPrefix(command, "ajudar") {
-		cm.logger.Info("Handling action command",
-			zap.String("command", command))
-		return cm.handleActionCommand(sender, command)
-	}
-
-	// Check if this is an event response
-	if command == "a" || command == "b" || command == "c" || command == "d" {
-		cm.logger.Info("Handling event response command",
-			zap.String("command", command))
-		return cm.handleEventResponseCommand(sender, command)
+	if response, handled := cm.commands.Dispatch(sender, chat, command); handled {
+		return response
 	}
 
 	// Unknown command
@@ -625,15 +681,7 @@ func (cm *ClientManager) processGameCommand(sender, command string) string {
 }
 
 // handleRegistrationCommand processes player registration
-func (cm *ClientManager) handleRegistrationCommand(sender, command string) string {
-	parts := strings.SplitN(command, " ", 2)
-	if len(parts) < 2 {
-		return "Ei, você esqueceu seu nome! 🧐\n\n" +
-			"Digite: */comecar [seu nome]*"
-	}
-
-	playerName := parts[1]
-
+func (cm *ClientManager) handleRegistrationCommand(sender, playerName string) string {
 	// Check if player is already registered
 	existingPlayer, _ := cm.gameManager.GetPlayer(sender)
 	if existingPlayer != nil {
@@ -664,7 +712,7 @@ func (cm *ClientManager) handleRegistrationCommand(sender, command string) strin
 }
 
 // handleSetupCommand sets up the host's WhatsApp connection
-func (cm *ClientManager) handleSetupCommand(sender, command string) string {
+func (cm *ClientManager) handleSetupCommand(sender string) string {
 	// Get QR channel for WhatsApp authentication
 	qrChan, err := cm.GetQRChannel(sender)
 	if err != nil {
@@ -718,19 +766,13 @@ func (cm *ClientManager) handleSetupCommand(sender, command string) string {
 }
 
 // handleCharacterSelectionCommand processes character selection
-func (cm *ClientManager) handleCharacterSelectionCommand(sender, command string) string {
-	// Extract character number from command
-	parts := strings.SplitN(command, " ", 2)
-	if len(parts) < 2 {
-		return "Para escolher um personagem, digite: escolher [número]"
-	}
-
+func (cm *ClientManager) handleCharacterSelectionCommand(sender, choice string) string {
 	// Get available characters
 	characters := cm.gameManager.GetAvailableCharacters()
 
 	// Parse character number
 	var characterIndex int
-	_, err := fmt.Sscanf(parts[1], "%d", &characterIndex)
+	_, err := fmt.Sscanf(choice, "%d", &characterIndex)
 	if err != nil || characterIndex < 1 || characterIndex > len(characters) {
 		return fmt.Sprintf("Número de personagem inválido. Escolha entre 1 e %d.", len(characters))
 	}
@@ -754,6 +796,12 @@ func (cm *ClientManager) handleCharacterSelectionCommand(sender, command string)
 			err.Error(), randomPhrase)
 	}
 
+	// Look up where the character starts
+	player, err := cm.gameManager.GetPlayer(sender)
+	if err != nil {
+		return fmt.Sprintf("Ops! Algo deu errado: %s 😱", err.Error())
+	}
+
 	// Send character selection confirmation
 	response := fmt.Sprintf("🎉 *PARABÉNS!* Você agora é *%s* %s\n\n"+
 		"*Seus atributos:*\n"+
@@ -762,14 +810,17 @@ func (cm *ClientManager) handleCharacterSelectionCommand(sender, command string)
 		"Rede: %d 🤝\n"+
 		"Moralidade: %d 👼\n"+
 		"Resiliência: %d 🥊\n\n"+
-		"Você acorda em *Copacabana* 🌊 com R$ 100,00 💰 e 0 XP ⭐\n\n"+
+		"Você acorda em *%s* 🗺️ com R$ %d,00 💰 e %d XP ⭐\n\n"+
 		"Digite */ajuda* para ver os comandos disponíveis! 🎮",
 		selectedCharacter.Name, getCharacterEmoji(selectedCharacter.Name),
 		selectedCharacter.Carisma,
 		selectedCharacter.Proficiencia,
 		selectedCharacter.Rede,
 		selectedCharacter.Moralidade,
-		selectedCharacter.Resiliencia)
+		selectedCharacter.Resiliencia,
+		cm.subZoneName(player.CurrentZone, player.CurrentSubZone),
+		player.Money,
+		player.XP)
 
 	return response
 }
@@ -791,11 +842,37 @@ func (cm *ClientManager) handleStatusCommand(sender string) string {
 	// Build response
 	response := fmt.Sprintf("📊 STATUS DE *%s* 📊\n\n", status["name"])
 	response += fmt.Sprintf("*Personagem*: %s (%s) 🎭\n", status["character"], status["character_type"])
-	response += fmt.Sprintf("*XP*: %d ⭐\n", status["xp"])
+	response += fmt.Sprintf("*Nível*: %d 🆙\n", status["level"])
+	if next := status["next_level_xp"].(int); next > 0 {
+		response += fmt.Sprintf("*XP*: %d/%d ⭐\n", status["xp"], next)
+	} else {
+		response += fmt.Sprintf("*XP*: %d ⭐\n", status["xp"])
+	}
 	response += fmt.Sprintf("*Dinheiro*: R$ %d,00 💵\n", status["money"])
 	response += fmt.Sprintf("*Influência*: %d 🎭\n", status["influence"])
-	response += fmt.Sprintf("*Estresse*: %d/100 💥\n", status["stress"])
-	response += fmt.Sprintf("*Localização*: %s 🗺️\n\n", status["location"])
+	response += fmt.Sprintf("*Estresse*: %d/100 (%s) 💥\n", status["stress"], status["stress_band"])
+	response += fmt.Sprintf("*Localização*: %s 🗺️\n", status["location"])
+	if heat, ok := status["heat"]; ok {
+		response += fmt.Sprintf("*Clima*: calor %d/100 🔥 · polícia %d/100 🚓\n", heat, status["police"])
+	}
+	if faction, ok := status["faction"]; ok {
+		response += fmt.Sprintf("*Facção*: %s 🏴\n", faction)
+	}
+	if arc, ok := status["arc"]; ok {
+		response += fmt.Sprintf("*História*: %s (etapa %d) 📖\n", arc, status["arc_step"])
+	}
+	response += fmt.Sprintf("*Hora no jogo*: %s 🕐\n", status["game_time"])
+	if seasons, ok := status["seasons"]; ok {
+		response += fmt.Sprintf("*Temporada*: %s 🎉\n", seasons)
+	}
+	if worldEvent, ok := status["world_event"]; ok {
+		response += fmt.Sprintf("*Agitação*: %s 🚨\n", worldEvent)
+	}
+	response += "\n"
+
+	if status["status"] == "burnout" {
+		response += "🔥 *BURNOUT!* Você só consegue descansar até o estresse baixar.\n\n"
+	}
 
 	response += "*ATRIBUTOS*:\n"
 	attributes := status["attributes"].(map[string]int)
@@ -805,11 +882,123 @@ func (cm *ClientManager) handleStatusCommand(sender string) string {
 	response += fmt.Sprintf("*Moralidade*: %d 👼\n", attributes["moralidade"])
 	response += fmt.Sprintf("*Resiliência*: %d 🥊\n", attributes["resiliencia"])
 
+	if points := status["points"].(int); points > 0 {
+		response += fmt.Sprintf("\nVocê tem *%d* ponto(s) pra gastar! Use */evoluir* 💪\n", points)
+	}
+
 	return response
 }
 
+// attributeLabels maps the attribute names players type to the game's names
+var attributeLabels = map[string]string{
+	"carisma":      "carisma",
+	"proficiencia": "proficiencia",
+	"proficiência": "proficiencia",
+	"rede":         "rede",
+	"moralidade":   "moralidade",
+	"resiliencia":  "resiliencia",
+	"resiliência":  "resiliencia",
+}
+
+// attributeDisplayNames maps the game's attribute names to how they are shown
+var attributeDisplayNames = map[string]string{
+	"carisma":      "Carisma",
+	"proficiencia": "Proficiência",
+	"rede":         "Rede",
+	"moralidade":   "Moralidade",
+	"resiliencia":  "Resiliência",
+}
+
+// handleEvolveCommand shows the player's attribute points or spends them on an attribute
+func (cm *ClientManager) handleEvolveCommand(sender, attribute, amount string) string {
+	player, err := cm.gameManager.GetPlayer(sender)
+	if err != nil {
+		return "Ei, você nem começou o jogo ainda! 😅\n\n" +
+			"Use */comecar [seu nome]* pra começar sua jornada!"
+	}
+
+	if player.CurrentCharacter == nil || player.Stats == nil {
+		return "Você ainda não escolheu um personagem! 🤔\n\n" +
+			"Use */personagens* pra ver quem você pode ser!"
+	}
+
+	if attribute == "" {
+		stats := player.Stats
+		return fmt.Sprintf("💪 *EVOLUIR* 💪\n\n"+
+			"Nível: *%d*\n"+
+			"Pontos para gastar: *%d*\n\n"+
+			"*Carisma*: %d 🎭\n"+
+			"*Proficiência*: %d 🧠\n"+
+			"*Rede*: %d 🤝\n"+
+			"*Moralidade*: %d 👼\n"+
+			"*Resiliência*: %d 🥊\n\n"+
+			"Use: */evoluir [atributo] [pontos]*\n"+
+			"Exemplo: */evoluir carisma 1*",
+			stats.Level, stats.AttributePoints,
+			stats.Carisma, stats.Proficiencia, stats.Rede, stats.Moralidade, stats.Resiliencia)
+	}
+
+	name, exists := attributeLabels[strings.ToLower(attribute)]
+	if !exists {
+		return fmt.Sprintf("Ei, não conheço o atributo *%s*! 🧐\n\n"+
+			"Escolha entre: carisma, proficiencia, rede, moralidade ou resiliencia", attribute)
+	}
+
+	points := 1
+	if amount != "" {
+		points, err = strconv.Atoi(amount)
+		if err != nil {
+			return "Ei, os pontos têm que ser um número! 🧐\n\nExemplo: */evoluir carisma 1*"
+		}
+	}
+
+	stats, err := cm.gameManager.SpendAttributePoints(sender, name, points)
+	if err != nil {
+		return fmt.Sprintf("Ops! Não deu pra evoluir: %s 😱", err.Error())
+	}
+
+	return fmt.Sprintf("✨ *EVOLUIU!* ✨\n\n"+
+		"*%s* +%d! Você tá ficando brabo. 😎\n\n"+
+		"Pontos restantes: *%d*",
+		attributeDisplayNames[name], points, stats.AttributePoints)
+}
+
+// handleEvolutionsCommand shows the player's progress toward each evolution path
+func (cm *ClientManager) handleEvolutionsCommand(sender string) string {
+	progress, err := cm.gameManager.GetEvolutionProgress(sender)
+	if err != nil {
+		if err.Error() == "jogador não encontrado" {
+			return "Ei, você nem começou o jogo ainda! 😅\n\n" +
+				"Use */comecar [seu nome]* pra começar sua jornada!"
+		}
+		return "Você ainda não escolheu um personagem! 🤔\n\n" +
+			"Use */personagens* pra ver quem você pode ser!"
+	}
+
+	if len(progress) == 0 {
+		return "🦋 *EVOLUÇÕES* 🦋\n\nVocê já chegou no topo da sua carreira. Não tem mais pra onde evoluir! 👑"
+	}
+
+	var response strings.Builder
+	response.WriteString("🦋 *EVOLUÇÕES* 🦋\n\n")
+	response.WriteString("Cumpra tudo de um caminho e você se transforma na hora:\n")
+
+	for _, p := range progress {
+		response.WriteString(fmt.Sprintf("\n*%s*\n_%s_\n", p.Evolution.Name, p.Evolution.Description))
+		for _, requirement := range p.Requirements {
+			mark := "❌"
+			if requirement.Met {
+				mark = "✅"
+			}
+			response.WriteString(fmt.Sprintf("%s %s\n", mark, requirement.Description))
+		}
+	}
+
+	return strings.TrimRight(response.String(), "\n")
+}
+
 // handleActionCommand processes player actions
-func (cm *ClientManager) handleActionCommand(sender, command string) string {
+func (cm *ClientManager) handleActionCommand(sender string, action *types.Action) string {
 	// Get player
 	player, err := cm.gameManager.GetPlayer(sender)
 	if err != nil {
@@ -823,10 +1012,13 @@ func (cm *ClientManager) handleActionCommand(sender, command string) string {
 			"Use */personagens* pra ver quem você pode ser!"
 	}
 
-	// Clean command
-	command = cleanCommand(command)
-	// Remove slash and convert to lowercase
-	command = strings.TrimPrefix(strings.ToLower(command), "/")
+	// Nobody works from the bus
+	if player.Travel != nil {
+		return fmt.Sprintf("🚌 Calma! Você ainda tá em trânsito para *%s*.\n\n"+
+			"Chegada prevista às *%s*. Aí você volta pro corre! ⏳",
+			cm.subZoneName(player.Travel.ToZone, player.Travel.ToSubZone),
+			player.Travel.ArrivesAt.Format("15:04"))
+	}
 
 	// Get available actions for current location
 	availableActions, err := cm.gameManager.GetAvailableActions(sender)
@@ -837,17 +1029,27 @@ func (cm *ClientManager) handleActionCommand(sender, command string) string {
 	// Check if the action is available in current zone
 	isAvailable := false
 	var actionNames []string
-	for _, action := range availableActions {
-		actionNames = append(actionNames, action.Name)
-		if command == action.Name {
+	for _, available := range availableActions {
+		actionNames = append(actionNames, available.Name)
+		if available.ID == action.ID {
 			isAvailable = true
 			break
 		}
 	}
 
+	// Burnout only leaves room for rest, and only rest is listed as available
+	if !isAvailable && player.Burnout {
+		message := "🔥 *BURNOUT!* 🔥\n\n" +
+			"Seu corpo e sua cabeça pediram arrego. Enquanto o estresse não baixar, você só consegue descansar.\n\n"
+		if len(actionNames) == 0 {
+			return message + "Não dá pra descansar aqui. Use */mover [subzona]* pra achar um lugar mais tranquilo! 🛌"
+		}
+		return message + fmt.Sprintf("*Dá pra fazer aqui:*\n%s", strings.Join(actionNames, ", "))
+	}
+
 	if !isAvailable {
 		// Format the subzone name properly
-		displayName := strings.Title(strings.ReplaceAll(player.CurrentSubZone, "_", " "))
+		displayName := cm.subZoneName(player.CurrentZone, player.CurrentSubZone)
 		actionList := strings.Join(actionNames, ", ")
 
 		return fmt.Sprintf("❌ *Ação não disponível em %s!*\n\n"+
@@ -857,7 +1059,7 @@ func (cm *ClientManager) handleActionCommand(sender, command string) string {
 	}
 
 	// Perform action
-	outcome, err := cm.gameManager.PerformAction(sender, command)
+	outcome, err := cm.gameManager.PerformAction(sender, action.ID)
 	if err != nil {
 		return fmt.Sprintf("Ops! Não deu pra fazer isso: %s 😱", err.Error())
 	}
@@ -885,16 +1087,7 @@ func (cm *ClientManager) handleActionCommand(sender, command string) string {
 }
 
 // handleEventResponseCommand processes player responses to events
-func (cm *ClientManager) handleEventResponseCommand(sender, command string) string {
-	// Clean command
-	command = cleanCommand(command)
-	// Remove slash and convert to lowercase
-	command = strings.TrimPrefix(strings.ToLower(command), "/")
-	// Take only the first character
-	if len(command) > 0 {
-		command = string(command[0])
-	}
-
+func (cm *ClientManager) handleEventResponseCommand(sender, letter string) string {
 	// Get player
 	player, err := cm.gameManager.GetPlayer(sender)
 	if err != nil {
@@ -913,34 +1106,23 @@ func (cm *ClientManager) handleEventResponseCommand(sender, command string) stri
 			"Continue explorando o mundo para encontrar eventos!"
 	}
 
-	// Map command letter to option index
-	optionIndex := -1
-	switch command {
-	case "a":
-		optionIndex = 0
-	case "b":
-		optionIndex = 1
-	case "c":
-		optionIndex = 2
-	case "d":
-		optionIndex = 3
-	default:
-		return "Opção inválida! Use /a, /b, /c ou /d para responder ao evento! 🎲"
-	}
-
-	// Check if option index is valid
+	// Map the option letter to its index in the event
+	optionIndex := int(letter[0] - 'a')
 	if optionIndex >= len(player.CurrentEvent.Options) {
-		return "Essa opção não está disponível para este evento! 🎲"
+		return fmt.Sprintf("Essa opção não está disponível para este evento! Use %s para responder! 🎲",
+			optionLetters(len(player.CurrentEvent.Options)))
 	}
 
 	// Get the option ID from the current event
 	optionID := player.CurrentEvent.Options[optionIndex].ID
-
-	// Store event ID before clearing it
 	eventID := player.CurrentEvent.ID
 
-	// Clear the current event before processing to prevent double-processing
-	player.CurrentEvent = nil
+	// Process event choice before the dice drama; the game manager clears the
+	// pending event, so a repeated answer can't be processed twice
+	outcome, err := cm.gameManager.ProcessEventChoice(sender, eventID, optionID)
+	if err != nil {
+		return fmt.Sprintf("Ops! Algo deu errado: %v 😱", err)
+	}
 
 	// Send dice rolling message
 	diceMessage := "🎲 *ROLANDO OS DADOS...* 🎲\n\n" +
@@ -970,13 +1152,6 @@ func (cm *ClientManager) handleEventResponseCommand(sender, command string) stri
 		time.Sleep(2 * time.Second)
 	}
 
-	// Process event choice
-	outcome, err := cm.gameManager.ProcessEventChoice(sender, eventID, optionID)
-	if err != nil {
-		// If there's an error, we should NOT restore the event since it might be invalid
-		return fmt.Sprintf("Ops! Algo deu errado: %v 😱", err)
-	}
-
 	// Build response
 	response := fmt.Sprintf("🎭 *RESULTADO DO EVENTO* 🎭\n\n")
 	response += fmt.Sprintf("%s\n\n", outcome.Description)
@@ -997,9 +1172,175 @@ func (cm *ClientManager) handleEventResponseCommand(sender, command string) stri
 		response += fmt.Sprintf("Estresse: %+d 💥\n", outcome.StressChange)
 	}
 
+	// A chained event that follows right away comes with the result
+	if outcome.NextEventID != "" {
+		if player, err := cm.gameManager.GetPlayer(sender); err == nil &&
+			player.CurrentEvent != nil && player.CurrentEvent.ID == outcome.NextEventID {
+			response += "\n" + formatChainedEvent(player.CurrentEvent)
+		}
+	}
+
 	return response
 }
 
+// formatChainedEvent shows the follow-up of an event chain with its options
+func formatChainedEvent(event *types.Event) string {
+	response := "📖 *A HISTÓRIA CONTINUA...* 📖\n\n"
+	if event.Title != "" {
+		response += fmt.Sprintf("*%s*\n", event.Title)
+	}
+	response += event.Description + "\n\n"
+
+	for i, option := range event.Options {
+		response += fmt.Sprintf("%s. %s\n", string(rune('A'+i)), option.Description)
+	}
+
+	return response + fmt.Sprintf("\nResponda com %s! 🎲", optionLetters(len(event.Options)))
+}
+
+// handleAutoPilotCommand toggles auto-pilot mode for a player
+func (cm *ClientManager) handleAutoPilotCommand(sender string) string {
+	player, err := cm.gameManager.GetPlayer(sender)
+	if err != nil {
+		return "Ei, você nem começou o jogo ainda! 😅\n\n" +
+			"Use */comecar [seu nome]* pra começar sua jornada!"
+	}
+
+	if player.CurrentCharacter == nil {
+		return "Você ainda não escolheu um personagem! 🤔\n\n" +
+			"Use */personagens* pra ver quem você pode ser!"
+	}
+
+	if player.Status == "autopilot" {
+		if err := cm.gameManager.SetPlayerStatus(sender, "active"); err != nil {
+			return fmt.Sprintf("Ops! Algo deu errado: %s 😱", err.Error())
+		}
+		return "🎮 *PILOTO AUTOMÁTICO DESLIGADO* 🎮\n\n" +
+			"Bem-vindo de volta! Agora é com você de novo."
+	}
+
+	if err := cm.gameManager.SetPlayerStatus(sender, "autopilot"); err != nil {
+		return fmt.Sprintf("Ops! Algo deu errado: %s 😱", err.Error())
+	}
+
+	return "🤖 *PILOTO AUTOMÁTICO LIGADO* 🤖\n\n" +
+		"Pode ir dormir! Eu respondo seus eventos e faço ações por você, " +
+		"e te mando um resumo de tudo que rolar.\n\n" +
+		"Mande qualquer mensagem para voltar ao controle."
+}
+
+// notificationCategoryNames maps what players type to notification categories
+var notificationCategoryNames = map[string]string{
+	"eventos":  "eventos",
+	"noticias": "noticias",
+	"notícias": "noticias",
+	"pvp":      "pvp",
+}
+
+// handleNotificationsCommand shows or changes the player's notification
+// settings: quiet hours, event frequency and message categories
+func (cm *ClientManager) handleNotificationsCommand(sender, option, value string) string {
+	if _, err := cm.gameManager.GetPlayer(sender); err != nil {
+		return "Ei, você nem começou o jogo ainda! 😅\n\n" +
+			"Use */comecar [seu nome]* pra começar sua jornada!"
+	}
+
+	option = strings.ToLower(option)
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	var err error
+	switch option {
+	case "":
+		settings, err := cm.gameManager.GetNotificationSettings(sender)
+		if err != nil {
+			return fmt.Sprintf("Ops! Algo deu errado: %s 😱", err.Error())
+		}
+		return formatNotificationSettings(settings)
+
+	case "silencio", "silêncio":
+		if value == "off" || value == "desligar" || value == "desliga" {
+			err = cm.gameManager.SetQuietHours(sender, nil)
+			break
+		}
+
+		fields := strings.FieldsFunc(value, func(r rune) bool { return r == ' ' || r == '-' || r == 'h' })
+		if len(fields) != 2 {
+			return "Ei, me diz quando começa e quando termina! 🧐\n\nExemplo: */notificacoes silencio 22 7*"
+		}
+		start, startErr := strconv.Atoi(fields[0])
+		end, endErr := strconv.Atoi(fields[1])
+		if startErr != nil || endErr != nil {
+			return "Ei, as horas têm que ser números! 🧐\n\nExemplo: */notificacoes silencio 22 7*"
+		}
+		err = cm.gameManager.SetQuietHours(sender, &types.QuietHours{Start: start, End: end})
+
+	case "frequencia", "frequência":
+		err = cm.gameManager.SetNotificationFrequency(sender, value)
+
+	default:
+		category, exists := notificationCategoryNames[option]
+		if !exists {
+			return fmt.Sprintf("Ei, não conheço a opção *%s*! 🧐\n\n"+
+				"Escolha entre: silencio, frequencia, eventos, noticias ou pvp", option)
+		}
+
+		switch value {
+		case "on", "ligar", "liga", "sim":
+			err = cm.gameManager.SetNotificationCategory(sender, category, true)
+		case "off", "desligar", "desliga", "nao", "não":
+			err = cm.gameManager.SetNotificationCategory(sender, category, false)
+		default:
+			return fmt.Sprintf("Ei, é pra ligar ou desligar? 🧐\n\nExemplo: */notificacoes %s off*", option)
+		}
+	}
+
+	if err != nil {
+		return fmt.Sprintf("Ops! Não deu pra mudar suas notificações: %s 😱", err.Error())
+	}
+
+	settings, err := cm.gameManager.GetNotificationSettings(sender)
+	if err != nil {
+		return fmt.Sprintf("Ops! Algo deu errado: %s 😱", err.Error())
+	}
+	return "✅ Pronto, anotado!\n\n" + formatNotificationSettings(settings)
+}
+
+// formatNotificationSettings formats a player's notification settings
+func formatNotificationSettings(settings *types.NotificationSettings) string {
+	quiet := "desligado"
+	if settings.QuietHours != nil {
+		quiet = fmt.Sprintf("das %dh às %dh 🌙", settings.QuietHours.Start, settings.QuietHours.End)
+	}
+
+	state := func(category string) string {
+		for _, muted := range settings.Muted {
+			if muted == category {
+				return "desligado 🔕"
+			}
+		}
+		return "ligado 🔔"
+	}
+
+	message := fmt.Sprintf("🔔 *NOTIFICAÇÕES* 🔔\n\n"+
+		"*Silêncio*: %s\n"+
+		"*Frequência de eventos*: %s\n"+
+		"*Eventos*: %s\n"+
+		"*Notícias*: %s\n"+
+		"*PvP*: %s\n",
+		quiet, settings.Frequency, state("eventos"), state("noticias"), state("pvp"))
+
+	if len(settings.Deferred) > 0 {
+		message += fmt.Sprintf("\nMensagens guardadas pra depois do silêncio: *%d* 📬\n", len(settings.Deferred))
+	}
+
+	message += "\nPra mudar:\n" +
+		"*/notificacoes silencio 22 7* - sem mensagens das 22h às 7h (ou *off*)\n" +
+		"*/notificacoes frequencia baixa* - baixa, normal ou alta\n" +
+		"*/notificacoes pvp off* - eventos, noticias ou pvp, *on* ou *off*"
+
+	return message
+}
+
 // handleCharactersListCommand returns the list of available characters
 func (cm *ClientManager) handleCharactersListCommand() string {
 	characters := cm.gameManager.GetAvailableCharacters()
@@ -1023,129 +1364,41 @@ func (cm *ClientManager) handleCharactersListCommand() string {
 	return response
 }
 
-// handleHelpCommand returns help information
-func (cm *ClientManager) handleHelpCommand() string {
-	response := "🎮 *VIDA LOKA STRATEGIA* - SEU GUIA DE SOBREVIVÊNCIA 🎮\n\n"
-
-	response += "🎯 *BÁSICOS* (PRA NÃO FICAR PERDIDO):\n"
-	response += "*/comecar [nome]* - Começa sua jornada de sucesso (ou fracasso) 🚀\n"
-	response += "*/começar [nome]* - Mesma coisa, só que com acento (pra quem é chique) 🎩\n"
-	response += "*/personagens* - Conheça os malucos que você pode ser 🎭\n"
-	response += "*/escolher [número]* - Escolha seu personagem (escolha sabiamente) 🤔\n"
-	response += "*/status* - Veja como tá sua vida (ou o que sobrou dela) 📊\n"
-	response += "*/ajuda* - Tá perdido? Chama o tio aqui! 🆘\n\n"
-
-	response += "💪 *AÇÕES PRINCIPAIS* (PRA GANHAR DINHEIRO):\n"
-	response += "*/trabalhar* - Trabalhe que nem um condenado 💼\n"
-	response += "*/estudar* - Estude que nem um nerd (mas vale a pena) 📚\n"
-	response += "*/relaxar* - Relaxe antes que você exploda 🧘‍♂️\n"
-	response += "*/curtir* - Curta a vida (mas não muito) 🎉\n"
-	response += "*/dormir* - Durma que nem um bebê (ou um morto) 😴\n\n"
-
-	response += "✨ *AÇÕES ADICIONAIS* (PRA FICAR MAIS FODA):\n"
-	response += "*/meditar* - Fique zen que nem um monge 🧘‍♂️\n"
-	response += "*/networking* - Faça amizades (ou inimigos) 🤝\n"
-	response += "*/treinar* - Fique forte que nem o Hulk 💪\n"
-	response += "*/empreender* - Vire o próximo Elon Musk (ou não) 🚀\n"
-	response += "*/ajudar* - Seja bonzinho (ou não) 👼\n\n"
-
-	response += "🏃‍♂️ *ZONAS E LOCOMOÇÃO* (PRA NÃO FICAR PARADO):\n"
-	response += "*/mover [subzona]* - Mude de lugar (antes que te peguem) 🏃‍♂️\n"
-	response += "*Zona Sul*: Copacabana, Ipanema, Leblon, Vidigal 🌊\n"
-	response += "*Zona Norte*: Madureira, Méier, Complexo do Alemão, Tijuca 🏙️\n"
-	response += "*Centro*: Lapa, SAARA, Cinelândia, Porto Maravilha 🎭\n"
-	response += "*Zona Oeste*: Barra da Tijuca, Jacarepaguá, Campo Grande, Santa Cruz 🌅\n\n"
-
-	response += "🎲 *ATRIBUTOS* (PRA FICAR MAIS INTELIGENTE):\n"
-	response += "Carisma: Habilidade de convencer até pedra 🎭\n"
-	response += "Proficiência: Saber fazer as coisas (ou fingir que sabe) 🧠\n"
-	response += "Rede: Ter amigos em todo lugar (ou inimigos) 🤝\n"
-	response += "Moralidade: Ser bonzinho (ou não) 👼\n"
-	response += "Resiliência: Aguentar pancada que nem um campeão 🥊\n\n"
-
-	response += "💰 *RECURSOS* (PRA NÃO FICAR NA MISÉRIA):\n"
-	response += "XP: Experiência de vida (ou de morte) ⭐\n"
-	response += "Dinheiro: O que move o mundo (e seu jogo) 💵\n"
-	response += "Influência: Poder de convencer os outros 🎭\n"
-	response += "Estresse: O que te faz explodir 💥\n\n"
-
-	response += "🎭 *EVENTOS* (PRA NÃO FICAR ENTEDIADO):\n"
-	response += "Responda a eventos com */a*, */b*, */c* ou */d* 🎲\n"
-	response += "Sucesso = 1d20 + atributo relevante (boa sorte!) 🍀\n\n"
-
-	response += "Boa sorte na sua jornada! Que a força esteja com você! 🍀✨"
-	return response
-}
-
 // handleMoveCommand processes player movement between zones
-func (cm *ClientManager) handleMoveCommand(sender, command string) string {
-	// Clean up the command by removing extra spaces
-	command = strings.TrimSpace(command)
-	parts := strings.Fields(command)
-	if len(parts) < 2 {
+func (cm *ClientManager) handleMoveCommand(sender, destination string) string {
+	if destination == "" {
 		return "Ei, você esqueceu pra onde vai! 🧐\n\n" +
 			"*Zonas disponíveis:*\n\n" +
-			"• *Zona Sul:* Copacabana, Ipanema, Leblon, Vidigal 🌊\n" +
-			"• *Zona Norte:* Madureira, Méier, Complexo do Alemão, Tijuca 🏙️\n" +
-			"• *Centro:* Lapa, SAARA, Cinelândia, Porto Maravilha 🎭\n" +
-			"• *Zona Oeste:* Barra da Tijuca, Jacarepaguá, Campo Grande, Santa Cruz 🌅\n\n" +
+			cm.zoneListing() + "\n\n" +
 			"Use: */mover [subzona]*\n" +
 			"Exemplo: */mover ipanema*"
 	}
 
-	// Join all parts after "mover" to handle multi-word subzones
-	subZoneInput := strings.ToLower(strings.Join(parts[1:], " "))
+	zone, subZone, err := cm.gameManager.FindLocation(destination)
+	if err != nil {
+		return fmt.Sprintf("Ei, não achei esse lugar! 🗺️\n\n_%s_\n\n", err.Error()) +
+			"*Zonas disponíveis:*\n\n" +
+			cm.zoneListing() + "\n\n" +
+			"Use: */mover [subzona]*\n" +
+			"Exemplo: */mover ipanema*"
+	}
 
 	// Check if the user is trying to move to a zone instead of a subzone
-	zoneNames := map[string]string{
-		"zona sul":   "zona_sul",
-		"zona norte": "zona_norte",
-		"centro":     "centro",
-		"zona oeste": "zona_oeste",
-	}
+	if subZone == nil {
+		var subZones strings.Builder
+		for i, sz := range zone.SubZones {
+			if i > 0 {
+				subZones.WriteString("\n")
+			}
+			subZones.WriteString("• " + sz.Name)
+		}
 
-	if zoneID, isZone := zoneNames[subZoneInput]; isZone {
 		return fmt.Sprintf("Ei, você precisa escolher uma subzona específica! 🗺️\n\n"+
 			"*Subzonas disponíveis em %s:*\n\n"+
 			"%s\n\n"+
 			"Use: */mover [subzona]*\n"+
-			"Exemplo: */mover ipanema*",
-			strings.Title(subZoneInput),
-			getSubzonesForZone(zoneID))
-	}
-
-	// Normalize the subzone ID
-	subZoneID := normalizeSubzoneName(subZoneInput)
-
-	zoneMap := map[string]string{
-		"copacabana":      "zona_sul",
-		"ipanema":         "zona_sul",
-		"leblon":          "zona_sul",
-		"vidigal":         "zona_sul",
-		"madureira":       "zona_norte",
-		"meier":           "zona_norte",
-		"complexo_alemao": "zona_norte",
-		"tijuca":          "zona_norte",
-		"lapa":            "centro",
-		"saara":           "centro",
-		"cinelandia":      "centro",
-		"porto_maravilha": "centro",
-		"barra_da_tijuca": "zona_oeste",
-		"jacarepagua":     "zona_oeste",
-		"campo_grande":    "zona_oeste",
-		"santa_cruz":      "zona_oeste",
-	}
-
-	zoneID, exists := zoneMap[subZoneID]
-	if !exists {
-		return "Ei, essa subzona não existe! 🗺️\n\n" +
-			"*Zonas disponíveis:*\n\n" +
-			"• *Zona Sul:* Copacabana, Ipanema, Leblon, Vidigal 🌊\n" +
-			"• *Zona Norte:* Madureira, Méier, Complexo do Alemão, Tijuca 🏙️\n" +
-			"• *Centro:* Lapa, SAARA, Cinelândia, Porto Maravilha 🎭\n" +
-			"• *Zona Oeste:* Barra da Tijuca, Jacarepaguá, Campo Grande, Santa Cruz 🌅\n\n" +
-			"Use: */mover [subzona]*\n" +
-			"Exemplo: */mover ipanema*"
+			"Exemplo: */mover %s*",
+			zone.Name, subZones.String(), strings.ToLower(zone.SubZones[0].Name))
 	}
 
 	player, err := cm.gameManager.GetPlayer(sender)
@@ -1159,158 +1412,420 @@ func (cm *ClientManager) handleMoveCommand(sender, command string) string {
 			"Use */personagens* pra ver quem você pode ser!"
 	}
 
-	err = cm.gameManager.MovePlayer(sender, zoneID, subZoneID)
+	quote, err := cm.gameManager.QuoteTravel(sender, zone.ID, subZone.ID)
 	if err != nil {
 		return fmt.Sprintf("Ops! Não deu pra mudar de lugar: %v 😱\n\n"+
 			"Tente de novo ou escolha outro lugar!", err)
 	}
 
-	// Get the display name without underscores
-	displayName := strings.Title(strings.ReplaceAll(subZoneID, "_", " "))
-
-	// Location-specific messages with multiple options
-	locationMessages := map[string][]string{
-		"campo_grande": {
-			"Você chegou em *Campo Grande*... que calor da porra! 🌡️🔥\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Campo Grande*... onde o ar-condicionado é artigo de luxo! ❄️💸\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Campo Grande*... terra do calor infernal e do suor eterno! 🔥💦\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Campo Grande*... onde até o ventilador pede arrego! 💨😓\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Campo Grande*... onde o sol é mais forte que sua vontade de trabalhar! ☀️😅\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Campo Grande*... onde até o termômetro desiste de medir! 🌡️🤯\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-		},
-		"lapa": {
-			"Você chegou na *Lapa*... só tem malandro e pivete aqui, fica ligado! 🎭👀\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou na *Lapa*... onde todo mundo é artista, menos os artistas! 🎨🎭\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou na *Lapa*... terra do samba, da cerveja e da ressaca! 🍺🎵\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou na *Lapa*... onde todo mundo tem uma história pra contar, mas ninguém acredita! 📖🤥\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou na *Lapa*... onde até o mendigo tem mais estilo que você! 👔🎩\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou na *Lapa*... onde a noite é mais movimentada que o dia! 🌙🎉\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-		},
-		"copacabana": {
-			"Você chegou em *Copacabana*... cuidado com os gringos e os preços! 💸🌍\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Copacabana*... onde todo mundo é turista, menos os turistas! 🧳👀\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Copacabana*... terra do biquíni fio dental e do dinheiro curto! 👙💸\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Copacabana*... onde até o picolé é importado! 🍦🌍\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Copacabana*... onde todo mundo é rico, menos você! 💰😅\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Copacabana*... onde até o mendigo fala inglês! 🗣️🌍\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-		},
-		"ipanema": {
-			"Você chegou em *Ipanema*... onde todo mundo é rico, menos você! 💰😅\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Ipanema*... onde até o cachorro tem pedigree! 🐕👑\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Ipanema*... terra do suco detox e do saldo negativo! 🥤💸\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Ipanema*... onde todo mundo é influencer, menos os influencers! 📱🎭\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Ipanema*... onde até o pão é artesanal! 🥖👨‍🍳\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Ipanema*... onde todo mundo tem iate, menos você! ⛵😅\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-		},
-		"leblon": {
-			"Você chegou no *Leblon*... tá vendo aquela mansão? Não é sua! 🏰😅\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *Leblon*... onde até o lixo é gourmet! 🗑️👨‍🍳\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *Leblon*... terra do suco verde e do cartão vermelho! 💳🥬\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *Leblon*... onde todo mundo tem helicóptero, menos você! 🚁😅\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *Leblon*... onde até o mendigo tem conta no exterior! 🌍💰\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *Leblon*... onde todo mundo é VIP, menos você! 🎫😅\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-		},
-		"vidigal": {
-			"Você chegou no *Vidigal*... subiu o morro, agora aguenta! ⛰️💪\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *Vidigal*... onde todo mundo é guerreiro! ⚔️🛡️\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *Vidigal*... terra do funk e da vista privilegiada! 🎵🌅\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *Vidigal*... onde todo mundo tem história pra contar! 📖🎭\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *Vidigal*... onde até o cachorro é valente! 🐕💪\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *Vidigal*... onde todo mundo é família! 👨‍👩‍👧‍👦❤️\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-		},
-		"madureira": {
-			"Você chegou em *Madureira*... terra do samba e do pagode! 🎵💃\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Madureira*... onde todo mundo é bamba! 🕺🎭\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Madureira*... terra do feijão com arroz e do samba no pé! 🍚💃\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Madureira*... onde todo mundo tem ginga! 💃🕺\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Madureira*... onde até o cachorro samba! 🐕💃\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Madureira*... onde todo mundo é bamba do samba! 🎭🎵\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-		},
-		"meier": {
-			"Você chegou no *Méier*... onde todo mundo tem um primo que conhece alguém! 🤝👥\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *Méier*... terra do cafezinho e da fofoca! ☕🗣️\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *Méier*... onde todo mundo é parente! 👨‍👩‍👧‍👦❤️\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *Méier*... onde até o cachorro tem QI! 🧠🐕\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *Méier*... onde todo mundo tem um jeitinho! 🎭🤝\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *Méier*... onde até o mendigo tem networking! 🤝👔\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-		},
-		"complexo_alemao": {
-			"Você chegou no *Complexo do Alemão*... fica esperto e não vacila! 🚨👀\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *Complexo do Alemão*... onde todo mundo é guerreiro! ⚔️🛡️\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *Complexo do Alemão*... terra do funk e da coragem! 🎵💪\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *Complexo do Alemão*... onde todo mundo tem história! 📖🎭\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *Complexo do Alemão*... onde até o cachorro é chapa quente! 🐕💪\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *Complexo do Alemão*... onde todo mundo é família! 👨‍👩‍👧‍👦❤️\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-		},
-		"tijuca": {
-			"Você chegou na *Tijuca*... onde todo mundo é formado e desempregado! 🎓😅\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou na *Tijuca*... terra do diploma e do Uber! 🚗🎓\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou na *Tijuca*... onde todo mundo tem currículo! 📄👔\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou na *Tijuca*... onde até o mendigo tem MBA! 🎓👨‍🎓\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou na *Tijuca*... onde todo mundo é especialista! 🧠👨‍💼\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou na *Tijuca*... onde até o cachorro tem LinkedIn! 💼🐕\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-		},
-		"saara": {
-			"Você chegou no *SAARA*... onde tudo é barato, menos o que você quer! 💰😅\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *SAARA*... terra da pechincha e do desconto! 🛍️💸\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *SAARA*... onde todo mundo é vendedor! 🏪👨‍💼\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *SAARA*... onde até o mendigo tem loja! 🏬👨‍💼\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *SAARA*... onde todo mundo tem preço! 💵💰\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *SAARA*... onde até o cachorro faz propaganda! 🐕📢\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-		},
-		"cinelandia": {
-			"Você chegou na *Cinelândia*... onde todo mundo é ator, menos os atores! 🎬🎭\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou na *Cinelândia*... terra do teatro e do desemprego! 🎭😅\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou na *Cinelândia*... onde todo mundo tem talento! 🎨🎭\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou na *Cinelândia*... onde até o mendigo tem Oscar! 🏆🎭\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou na *Cinelândia*... onde todo mundo é estrela! ⭐🎭\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou na *Cinelândia*... onde até o cachorro tem agente! 🎭🐕\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-		},
-		"porto_maravilha": {
-			"Você chegou no *Porto Maravilha*... onde tudo é novo, menos o preço! 🏗️💸\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *Porto Maravilha*... terra da gentrificação e do aluguel caro! 💸🏢\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *Porto Maravilha*... onde todo mundo é hipster! 🧔🎨\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *Porto Maravilha*... onde até o mendigo tem bike! 🚲👨‍💼\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *Porto Maravilha*... onde todo mundo é moderno! 🏢🎨\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou no *Porto Maravilha*... onde até o cachorro tem café artesanal! ☕🐕\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-		},
-		"barra_da_tijuca": {
-			"Você chegou na *Barra*... onde todo mundo tem carro, menos você! 🚗😅\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou na *Barra*... terra do trânsito e do condomínio fechado! 🏘️🚗\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou na *Barra*... onde todo mundo tem piscina! 🏊🏠\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou na *Barra*... onde até o mendigo tem carro importado! 🚘👨‍💼\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou na *Barra*... onde todo mundo é playboy! 🏄👨‍💼\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou na *Barra*... onde até o cachorro tem coleira de ouro! 🐕💰\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-		},
-		"jacarepagua": {
-			"Você chegou em *Jacarepaguá*... onde todo mundo é do Flamengo! 🔴⚫\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Jacarepaguá*... terra do samba e do futebol! ⚽🎵\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Jacarepaguá*... onde todo mundo é rubro-negro! 🔴⚫\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Jacarepaguá*... onde até o mendigo tem camisa do Flamengo! 👕🔴\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Jacarepaguá*... onde todo mundo é Mengão! 🏆🔴\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Jacarepaguá*... onde até o cachorro é flamenguista! 🐕🔴\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-		},
-		"santa_cruz": {
-			"Você chegou em *Santa Cruz*... onde todo mundo tem um tio que trabalha na fábrica! 🏭👨‍🏭\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Santa Cruz*... terra da indústria e do churrasco! 🍖🏭\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Santa Cruz*... onde todo mundo tem emprego! 💼👨‍💼\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Santa Cruz*... onde até o mendigo tem carteira assinada! 📄👨‍💼\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Santa Cruz*... onde todo mundo é operário! 👷🏭\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-			"Você chegou em *Santa Cruz*... onde até o cachorro tem crachá! 🐕👨‍💼\n\nBem-vindo ao seu novo cantinho! 🏠✨",
-		},
-	}
-
-	// Get the messages for this location, or use a default one
-	messages, exists := locationMessages[subZoneID]
-	if !exists {
-		return fmt.Sprintf("Você chegou em %s ‍♂️\n\nBem-vindo ao seu novo cantinho! 🏠", displayName)
+	return fmt.Sprintf("🗺️ *VIAGEM PARA %s*\n\n"+
+		"💰 Custo: R$ %d,00\n"+
+		"💥 Estresse: +%d\n"+
+		"⏱️ Tempo: %d minutos no relógio do jogo\n"+
+		"⚠️ Risco no destino: %d/10\n\n"+
+		"Digite */confirmar* pra partir ou */mover [outro lugar]* pra ver outro destino!",
+		strings.ToUpper(subZone.Name), quote.Cost, quote.StressCost, quote.DurationMinutes, quote.RiskLevel)
+}
+
+// handleConfirmTravelCommand starts the trip quoted by the last /mover
+func (cm *ClientManager) handleConfirmTravelCommand(sender string) string {
+	if _, err := cm.gameManager.GetPlayer(sender); err != nil {
+		return "Ei, você nem começou o jogo ainda! 😅\n\n" +
+			"Use */comecar [seu nome]* pra começar sua jornada!"
 	}
 
-	// Select a random message
-	rand.Seed(time.Now().UnixNano())
-	message := messages[rand.Intn(len(messages))]
+	travel, err := cm.gameManager.ConfirmTravel(sender)
+	if err != nil {
+		return fmt.Sprintf("Ops! Não deu pra partir: %v 😱\n\n"+
+			"Use */mover [subzona]* pra ver quanto custa a viagem!", err)
+	}
 
-	return message
+	return fmt.Sprintf("🚌 *PARTIU!*\n\n"+
+		"Você tá em trânsito para *%s*.\n"+
+		"Chegada prevista às *%s*. Fica esperto no caminho! 👀",
+		cm.subZoneName(travel.ToZone, travel.ToSubZone),
+		travel.ArrivesAt.Format("15:04"))
+}
+
+// interactionNames maps interaction kinds to their display names
+var interactionNames = map[string]string{
+	"desafiar": "Desafio",
+	"roubar":   "Roubo",
+	"ajudar":   "Ajuda",
+	"pagar":    "Pagamento",
+}
+
+// handleInteractionCommand starts an interaction with another player. For
+// payments the value comes last, after the player's name.
+func (cm *ClientManager) handleInteractionCommand(sender, kind, target string) string {
+	if _, err := cm.gameManager.GetPlayer(sender); err != nil {
+		return "Ei, você nem começou o jogo ainda! 😅\n\n" +
+			"Use */comecar [seu nome]* pra começar sua jornada!"
+	}
+
+	amount := 0
+	if kind == "pagar" {
+		fields := strings.Fields(target)
+		if len(fields) < 2 {
+			return "Ei, faltou o valor! 🧐\n\nUse: */pagar [@nome] [valor]*"
+		}
+
+		value, err := strconv.Atoi(fields[len(fields)-1])
+		if err != nil {
+			return "Ei, o valor tem que ser um número! 🧐\n\nExemplo: */pagar @Ana 50*"
+		}
+		amount = value
+		target = strings.Join(fields[:len(fields)-1], " ")
+	}
+
+	interaction, err := cm.gameManager.StartInteraction(sender, kind, target, amount)
+	if err != nil {
+		return fmt.Sprintf("Ops! Não deu: %s 😱", err.Error())
+	}
+
+	if interaction.Result != nil {
+		return formatInteractionResult(interaction, sender)
+	}
+
+	return fmt.Sprintf("📨 Convite enviado pra *%s*!\n\n"+
+		"Agora é esperar a resposta. ⏳", interaction.ToName)
+}
+
+// handleInteractionResponseCommand accepts or declines the interaction
+// waiting for the player's answer
+func (cm *ClientManager) handleInteractionResponseCommand(sender string, accept bool) string {
+	if _, err := cm.gameManager.GetPlayer(sender); err != nil {
+		return "Ei, você nem começou o jogo ainda! 😅\n\n" +
+			"Use */comecar [seu nome]* pra começar sua jornada!"
+	}
+
+	interaction, err := cm.gameManager.RespondToInteraction(sender, accept)
+	if err != nil {
+		if errors.Is(err, interfaces.ErrNoPendingInteraction) {
+			return "Ninguém te chamou pra nada (ou o convite já venceu). 🤷"
+		}
+		return fmt.Sprintf("Ops! Não deu: %s 😱", err.Error())
+	}
+
+	if !accept {
+		return fmt.Sprintf("Você recusou o convite de *%s*. 🙅", interaction.FromName)
+	}
+
+	return formatInteractionResult(interaction, sender)
+}
+
+// formatInteractionResult shows the result of an interaction from the side
+// of the given player
+func formatInteractionResult(interaction *types.Interaction, phoneNumber string) string {
+	result := interaction.Result
+
+	outcome, mine, theirs := result.FromOutcome, result.FromRoll, result.ToRoll
+	if phoneNumber == interaction.ToPhone {
+		outcome, mine, theirs = result.ToOutcome, result.ToRoll, result.FromRoll
+	}
+
+	response := fmt.Sprintf("🎲 *%s* 🎲\n\n%s\n\n🎲 Rolagem: %d x %d",
+		strings.ToUpper(interactionNames[interaction.Kind]), outcome.Description, mine, theirs)
+
+	if outcome.XPChange != 0 {
+		response += fmt.Sprintf("\n⭐ XP: %+d", outcome.XPChange)
+	}
+
+	if outcome.MoneyChange != 0 {
+		response += fmt.Sprintf("\n💰 Dinheiro: R$ %+d,00", outcome.MoneyChange)
+	}
+
+	if outcome.InfluenceChange != 0 {
+		response += fmt.Sprintf("\n🎭 Influência: %+d", outcome.InfluenceChange)
+	}
+
+	if outcome.StressChange != 0 {
+		response += fmt.Sprintf("\n💥 Estresse: %+d", outcome.StressChange)
+	}
+
+	return response
+}
+
+// handleFactionCommand shows the player's faction: members, bank and territories
+func (cm *ClientManager) handleFactionCommand(sender string) string {
+	faction, err := cm.gameManager.GetPlayerFaction(sender)
+	if err != nil {
+		return "Ei, você nem começou o jogo ainda! 😅\n\n" +
+			"Use */comecar [seu nome]* pra começar sua jornada!"
+	}
+
+	if faction == nil {
+		return "🏴 *FACÇÃO* 🏴\n\n" +
+			"Você ainda não tem facção. Sozinho ninguém chega longe! 🤝\n\n" +
+			"Use */fundar [nome]* pra criar a sua ou peça um convite pra alguém."
+	}
+
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("🏴 *%s* 🏴\n\n", strings.ToUpper(faction.Name)))
+	response.WriteString(fmt.Sprintf("*Caixa*: R$ %d,00 💰\n\n", faction.Bank))
+
+	response.WriteString("*Membros*:\n")
+	for _, member := range faction.Members {
+		if member.Role == "leader" {
+			response.WriteString(fmt.Sprintf("👑 %s\n", member.Name))
+		} else {
+			response.WriteString(fmt.Sprintf("• %s\n", member.Name))
+		}
+	}
+
+	response.WriteString("\n*Território*:\n")
+	if len(faction.Territories) == 0 {
+		response.WriteString("Nenhum ainda. O líder pode usar */dominar* onde estiver.\n")
+	}
+	for _, territory := range faction.Territories {
+		control := cm.gameManager.GetSubZoneState(territory.Zone, territory.SubZone).Control
+		response.WriteString(fmt.Sprintf("🚩 %s (controle %d%%)\n", cm.subZoneName(territory.Zone, territory.SubZone), control))
+	}
+
+	return strings.TrimRight(response.String(), "\n")
+}
+
+// handleCreateFactionCommand founds a faction led by the player
+func (cm *ClientManager) handleCreateFactionCommand(sender, name string) string {
+	faction, err := cm.gameManager.CreateFaction(sender, name)
+	if err != nil {
+		return fmt.Sprintf("Ops! Não deu pra fundar a facção: %s 😱", err.Error())
+	}
+
+	return fmt.Sprintf("🏴 *FACÇÃO FUNDADA!* 🏴\n\n"+
+		"Você agora é o líder da *%s*! 👑\n\n"+
+		"Use */convidar [@nome]* pra chamar a galera e */contribuir [valor]* pra encher o caixa.",
+		faction.Name)
+}
+
+// handleInviteToFactionCommand invites another player into the leader's faction
+func (cm *ClientManager) handleInviteToFactionCommand(sender, target string) string {
+	faction, err := cm.gameManager.InviteToFaction(sender, target)
+	if err != nil {
+		return fmt.Sprintf("Ops! Não deu pra convidar: %s 😱", err.Error())
+	}
+
+	return fmt.Sprintf("📨 Convite da *%s* enviado! Agora é esperar. ⏳", faction.Name)
+}
+
+// handleJoinFactionCommand puts the player in a faction that invited them
+func (cm *ClientManager) handleJoinFactionCommand(sender, name string) string {
+	faction, err := cm.gameManager.JoinFaction(sender, name)
+	if err != nil {
+		return fmt.Sprintf("Ops! Não deu pra entrar: %s 😱", err.Error())
+	}
+
+	return fmt.Sprintf("🏴 Bem-vindo à *%s*! 🤝\n\n"+
+		"Use */faccao* pra ver quem tá com você.", faction.Name)
+}
+
+// handleLeaveFactionCommand takes the player out of their faction
+func (cm *ClientManager) handleLeaveFactionCommand(sender string) string {
+	faction, err := cm.gameManager.LeaveFaction(sender)
+	if err != nil {
+		return fmt.Sprintf("Ops! Não deu pra sair: %s 😱", err.Error())
+	}
+
+	if len(faction.Members) == 0 {
+		return fmt.Sprintf("🏴 Você saiu e a *%s* acabou. Fim de uma era. 🥀", faction.Name)
+	}
+	return fmt.Sprintf("🚪 Você saiu da *%s*.", faction.Name)
+}
+
+// handleKickFromFactionCommand removes a member from the leader's faction
+func (cm *ClientManager) handleKickFromFactionCommand(sender, target string) string {
+	faction, err := cm.gameManager.KickFromFaction(sender, target)
+	if err != nil {
+		return fmt.Sprintf("Ops! Não deu pra expulsar: %s 😱", err.Error())
+	}
+
+	return fmt.Sprintf("🚪 Expulso da *%s*. Quem manda é você! 👑", faction.Name)
+}
+
+// handleContributeCommand moves the player's money to their faction's bank
+func (cm *ClientManager) handleContributeCommand(sender, amount string) string {
+	value, err := strconv.Atoi(amount)
+	if err != nil {
+		return "Ei, o valor tem que ser um número! 🧐\n\nExemplo: */contribuir 100*"
+	}
+
+	faction, err := cm.gameManager.ContributeToFaction(sender, value)
+	if err != nil {
+		return fmt.Sprintf("Ops! Não deu pra contribuir: %s 😱", err.Error())
+	}
+
+	return fmt.Sprintf("💰 Você colocou *R$ %d,00* no caixa da *%s*!\n\n"+
+		"Caixa agora: *R$ %d,00*", value, faction.Name, faction.Bank)
+}
+
+// handleClaimTerritoryCommand claims the leader's current subzone for their faction
+func (cm *ClientManager) handleClaimTerritoryCommand(sender string) string {
+	faction, err := cm.gameManager.ClaimTerritory(sender)
+	if err != nil {
+		return fmt.Sprintf("Ops! Não deu pra dominar: %s 😱", err.Error())
+	}
+
+	territory := faction.Territories[len(faction.Territories)-1]
+	return fmt.Sprintf("🚩 *%s* agora é da *%s*! 💪\n\n"+
+		"Os membros ganham mais com ações por aqui, e quanto mais aparecerem, mais firme fica o controle.\n"+
+		"Caixa agora: *R$ %d,00*",
+		cm.subZoneName(territory.Zone, territory.SubZone), faction.Name, faction.Bank)
+}
+
+// handleMissionCommand opens a mission in the group chat, or shows the one
+// running there. Without a name and a mission it lists the missions.
+func (cm *ClientManager) handleMissionCommand(sender, chat, name string) string {
+	if _, err := cm.gameManager.GetPlayer(sender); err != nil {
+		return "Ei, você nem começou o jogo ainda! 😅\n\n" +
+			"Use */comecar [seu nome]* pra começar sua jornada!"
+	}
+
+	if chat == "" {
+		return "👥 Missões só rolam em grupo! Chama a galera num grupo com o bot e manda */ missao*."
+	}
+
+	if name == "" {
+		if mission, err := cm.gameManager.GetGroupMission(chat); err == nil {
+			return formatGroupMission(mission)
+		}
+
+		var response strings.Builder
+		response.WriteString("🎯 *MISSÕES* 🎯\n\n")
+		for _, mission := range cm.gameManager.GetMissions() {
+			response.WriteString(fmt.Sprintf("• *%s* (%d a %d jogadores): %s\n",
+				mission.Title, mission.MinPlayers, mission.MaxPlayers, mission.Description))
+		}
+		response.WriteString("\nUse */ missao [nome]* pra abrir uma!")
+		return response.String()
+	}
+
+	mission, err := cm.gameManager.StartGroupMission(chat, sender, name)
+	if err != nil {
+		return fmt.Sprintf("Ops! Não deu pra abrir a missão: %s 😱", err.Error())
+	}
+
+	return fmt.Sprintf("🎯 *MISSÃO ABERTA: %s* 🎯\n\n%s\n\n"+
+		"Precisa de %d a %d jogadores. Quem topar manda */ participar*!\n"+
+		"Começa em %d min, ou quando *%s* mandar */ partiu*.",
+		strings.ToUpper(mission.Mission.Title), mission.Mission.Description,
+		mission.Mission.MinPlayers, mission.Mission.MaxPlayers,
+		int(time.Until(mission.DeadlineAt).Round(time.Minute).Minutes()), mission.Participants[0].Name)
+}
+
+// handleJoinMissionCommand puts the player in the mission recruiting in the
+// group chat
+func (cm *ClientManager) handleJoinMissionCommand(sender, chat string) string {
+	mission, err := cm.gameManager.JoinGroupMission(chat, sender)
+	if err != nil {
+		return fmt.Sprintf("Ops! Não deu pra participar: %s 😱", err.Error())
+	}
+
+	participant := mission.Participants[len(mission.Participants)-1]
+	return fmt.Sprintf("🙋 *%s* tá dentro! (%d/%d)", participant.Name, len(mission.Participants), mission.Mission.MaxPlayers)
+}
+
+// handleLaunchMissionCommand starts the mission recruiting in the group chat
+func (cm *ClientManager) handleLaunchMissionCommand(sender, chat string) string {
+	mission, err := cm.gameManager.LaunchGroupMission(chat, sender)
+	if err != nil {
+		return fmt.Sprintf("Ops! Não deu pra começar: %s 😱", err.Error())
+	}
+
+	return fmt.Sprintf("🚀 Partiu *%s*!", mission.Mission.Title)
+}
+
+// handleVoteMissionCommand records the player's vote on the current step of
+// the mission in the group chat
+func (cm *ClientManager) handleVoteMissionCommand(sender, chat, choice string) string {
+	option, err := cm.gameManager.VoteInGroupMission(chat, sender, choice)
+	if err != nil {
+		return fmt.Sprintf("Ops! Não deu pra votar: %s 😱", err.Error())
+	}
+
+	return fmt.Sprintf("🗳️ Voto registrado: *%s*", option.Description)
+}
+
+// handleBetrayMissionCommand makes the player try to run off with the pot of
+// the mission in the group chat
+func (cm *ClientManager) handleBetrayMissionCommand(sender, chat string) string {
+	outcome, err := cm.gameManager.BetrayGroupMission(chat, sender)
+	if err != nil {
+		return fmt.Sprintf("Ops! Não deu pra trair: %s 😱", err.Error())
+	}
+
+	response := "🐍 *TRAIÇÃO!* 🐍\n\n" + outcome.Description
+
+	if outcome.XPChange != 0 {
+		response += fmt.Sprintf("\n⭐ XP: %+d", outcome.XPChange)
+	}
+
+	if outcome.MoneyChange != 0 {
+		response += fmt.Sprintf("\n💰 Dinheiro: R$ %+d,00", outcome.MoneyChange)
+	}
+
+	if outcome.InfluenceChange != 0 {
+		response += fmt.Sprintf("\n🎭 Influência: %+d", outcome.InfluenceChange)
+	}
+
+	if outcome.StressChange != 0 {
+		response += fmt.Sprintf("\n💥 Estresse: %+d", outcome.StressChange)
+	}
+
+	return response
+}
+
+// formatGroupMission shows a running mission: who is in it and where it is
+func formatGroupMission(mission *types.GroupMission) string {
+	var names []string
+	for _, participant := range mission.Participants {
+		if participant.Betrayed {
+			continue
+		}
+		names = append(names, participant.Name)
+	}
+
+	response := fmt.Sprintf("🎯 *%s* 🎯\n\n*Equipe*: %s\n", strings.ToUpper(mission.Mission.Title), strings.Join(names, ", "))
+
+	if mission.Status == "recruiting" {
+		return response + fmt.Sprintf("\nRecrutando (%d/%d). Manda */ participar* pra entrar!",
+			len(mission.Participants), mission.Mission.MaxPlayers)
+	}
+
+	step := mission.Mission.Steps[mission.Step]
+	response += fmt.Sprintf("*Pote*: R$ %d,00 💰\n\nEtapa %d/%d: %s\n\n",
+		mission.Pot, mission.Step+1, len(mission.Mission.Steps), step.Description)
+	for i, option := range step.Options {
+		response += fmt.Sprintf("%s. %s (%s)\n", string(rune('A'+i)), option.Description, option.RequiredAttribute)
+	}
+
+	return response + "\nVote com */ votar [letra]*!"
+}
+
+// zoneListing lists every zone with its subzones from the loaded catalog
+func (cm *ClientManager) zoneListing() string {
+	var listing []string
+	for _, zone := range cm.gameManager.GetZones() {
+		names := make([]string, len(zone.SubZones))
+		for i, subZone := range zone.SubZones {
+			names[i] = subZone.Name
+		}
+		listing = append(listing, fmt.Sprintf("• *%s:* %s", zone.Name, strings.Join(names, ", ")))
+	}
+
+	return strings.Join(listing, "\n")
+}
+
+// subZoneName returns the display name of a subzone, falling back to its ID
+func (cm *ClientManager) subZoneName(zoneID, subZoneID string) string {
+	if zone, err := cm.gameManager.GetZone(zoneID); err == nil {
+		for _, subZone := range zone.SubZones {
+			if subZone.ID == subZoneID {
+				return subZone.Name
+			}
+		}
+	}
+
+	return subZoneID
 }
 
 // sendResponse sends a response message
@@ -1334,6 +1849,19 @@ func (cm *ClientManager) sendResponse(phoneNumber string, targetJID waTypes.JID,
 	return response.ID, nil
 }
 
+// optionLetters lists the commands that answer an event with n options, e.g. "/a, /b ou /c"
+func optionLetters(n int) string {
+	letters := make([]string, n)
+	for i := range letters {
+		letters[i] = "/" + string(rune('a'+i))
+	}
+
+	if n <= 1 {
+		return strings.Join(letters, "")
+	}
+	return strings.Join(letters[:n-1], ", ") + " ou " + letters[n-1]
+}
+
 // cleanCommand normalizes and cleans a command string
 func cleanCommand(command string) string {
 	// Convert to lowercase
@@ -1404,49 +1932,6 @@ func getCharacterEmoji(name string) string {
 	}
 }
 
-// Helper function to get available actions for a zone
-func getAvailableActions(zoneID, subZoneID string) []string {
-	// This is a simplified version - in a real implementation, you would
-	// get this from your game state or configuration
-	switch zoneID {
-	case "zona_sul":
-		switch subZoneID {
-		case "copacabana", "ipanema", "leblon":
-			return []string{"trabalhar", "relaxar", "curtir", "dormir", "networking", "treinar", "meditar", "empreender"}
-		case "vidigal":
-			return []string{"trabalhar", "relaxar", "curtir", "dormir", "ajudar", "treinar"}
-		}
-	case "zona_norte":
-		switch subZoneID {
-		case "madureira", "meier", "tijuca":
-			return []string{"trabalhar", "estudar", "relaxar", "curtir", "dormir", "networking", "treinar", "empreender"}
-		case "complexo_alemao":
-			return []string{"trabalhar", "estudar", "relaxar", "dormir", "ajudar"}
-		}
-	case "centro":
-		switch subZoneID {
-		case "lapa":
-			return []string{"trabalhar", "curtir", "dormir", "networking", "empreender"}
-		case "saara":
-			return []string{"trabalhar", "relaxar", "dormir", "empreender"}
-		case "cinelandia":
-			return []string{"trabalhar", "estudar", "curtir", "dormir", "networking", "ajudar"}
-		case "porto_maravilha":
-			return []string{"trabalhar", "estudar", "relaxar", "curtir", "dormir", "networking", "empreender"}
-		}
-	case "zona_oeste":
-		switch subZoneID {
-		case "barra_da_tijuca":
-			return []string{"trabalhar", "estudar", "relaxar", "curtir", "dormir", "networking", "treinar", "meditar", "empreender"}
-		case "jacarepagua", "campo_grande":
-			return []string{"trabalhar", "estudar", "relaxar", "dormir", "ajudar", "treinar", "empreender"}
-		case "santa_cruz":
-			return []string{"trabalhar", "estudar", "relaxar", "dormir", "ajudar"}
-		}
-	}
-	return []string{}
-}
-
 // GetBotPhoneNumber returns the phone number of the bot's WhatsApp account
 func (cm *ClientManager) GetBotPhoneNumber() (string, error) {
 	cm.mutex.RLock()
@@ -1467,60 +1952,6 @@ func (cm *ClientManager) SendMessage(phoneNumber, recipient, message string) (st
 	return cm.SendTextMessage(phoneNumber, recipient, message)
 }
 
-// Helper function to get subzones for a zone
-func getSubzonesForZone(zoneID string) string {
-	switch zoneID {
-	case "zona_sul":
-		return "• Copacabana 🌊\n• Ipanema 🌊\n• Leblon 🌊\n• Vidigal 🌊"
-	case "zona_norte":
-		return "• Madureira 🏙️\n• Méier 🏙️\n• Complexo do Alemão 🏙️\n• Tijuca 🏙️"
-	case "centro":
-		return "• Lapa 🎭\n• SAARA 🎭\n• Cinelândia 🎭\n• Porto Maravilha 🎭"
-	case "zona_oeste":
-		return "• Barra da Tijuca 🌅\n• Jacarepaguá 🌅\n• Campo Grande 🌅\n• Santa Cruz 🌅"
-	default:
-		return ""
-	}
-}
-
-// Helper function to normalize subzone names
-func normalizeSubzoneName(name string) string {
-	// Convert to lowercase
-	name = strings.ToLower(name)
-
-	// Replace spaces with underscores
-	name = strings.ReplaceAll(name, " ", "_")
-
-	// Remove accents and special characters
-	replacements := map[string]string{
-		"á": "a", "à": "a", "â": "a", "ã": "a",
-		"é": "e", "è": "e", "ê": "e",
-		"í": "i", "ì": "i", "î": "i",
-		"ó": "o", "ò": "o", "ô": "o", "õ": "o",
-		"ú": "u", "ù": "u", "û": "u",
-		"ç":  "c",
-		"do": "do", // Keep "do" as is
-		"da": "da", // Keep "da" as is
-	}
-
-	for old, new := range replacements {
-		name = strings.ReplaceAll(name, old, new)
-	}
-
-	// Special cases for specific subzones
-	specialCases := map[string]string{
-		"complexo_do_alemao": "complexo_alemao",
-		"barra_da_tijuca":    "barra_da_tijuca",
-		"porto_maravilha":    "porto_maravilha",
-	}
-
-	if normalized, exists := specialCases[name]; exists {
-		return normalized
-	}
-
-	return name
-}
-
 // Random phrases for character selection errors
 var characterErrorPhrases = []string{
 	"Tá querendo ser fantasma antes da hora, parceiro?",
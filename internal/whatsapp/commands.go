@@ -0,0 +1,595 @@
+package whatsapp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/user/vida-loka-strategy/internal/types"
+)
+
+// CommandArg describes an argument of a command
+type CommandArg struct {
+	// Name shown in the usage, e.g. "nome"
+	Name string
+
+	// Whether the command is rejected without it
+	Required bool
+
+	// Whether it takes the rest of the message, for values with spaces
+	Rest bool
+}
+
+// CommandContext is what a handler gets when its command is invoked
+type CommandContext struct {
+	// Phone number of the player who sent the command
+	Sender string
+
+	// JID of the group chat the command was sent in; empty in private chats
+	Chat string
+
+	// Name the command was invoked with (may be an alias)
+	Name string
+
+	// Parsed arguments, in the order of the command's schema; missing
+	// optional arguments are empty strings
+	Args []string
+}
+
+// Arg returns the i-th argument, or an empty string if it wasn't given
+func (ctx *CommandContext) Arg(i int) string {
+	if i < len(ctx.Args) {
+		return ctx.Args[i]
+	}
+	return ""
+}
+
+// Command is an entry in the command registry
+type Command struct {
+	// Name typed after the slash
+	Name string
+
+	// Other names that invoke the same command
+	Aliases []string
+
+	// Argument schema
+	Args []CommandArg
+
+	// Help category (see commandCategories)
+	Category string
+
+	// One-line description shown in /ajuda
+	Help string
+
+	// Usage shown in /ajuda instead of the one built from Name and Args
+	Usage string
+
+	// Hidden commands work but are left out of /ajuda
+	Hidden bool
+
+	// Match accepts names that aren't listed, for pattern commands
+	Match func(name string) bool
+
+	// Handler runs the command and returns the reply
+	Handler func(ctx *CommandContext) string
+}
+
+// usage returns how the command is typed, e.g. "*/comecar [nome]*"
+func (c *Command) usage() string {
+	if c.Usage != "" {
+		return c.Usage
+	}
+
+	usage := "/" + c.Name
+	for _, arg := range c.Args {
+		usage += fmt.Sprintf(" [%s]", arg.Name)
+	}
+	return "*" + usage + "*"
+}
+
+// parseArgs splits the text after the command name according to the schema
+func (c *Command) parseArgs(text string) ([]string, error) {
+	fields := strings.Fields(text)
+	args := make([]string, len(c.Args))
+
+	for i, arg := range c.Args {
+		if arg.Rest {
+			args[i] = strings.Join(fields, " ")
+			fields = nil
+		} else if len(fields) > 0 {
+			args[i] = fields[0]
+			fields = fields[1:]
+		}
+
+		if arg.Required && args[i] == "" {
+			return nil, fmt.Errorf("faltou *%s*", arg.Name)
+		}
+	}
+
+	return args, nil
+}
+
+// commandCategory groups commands in /ajuda
+type commandCategory struct {
+	ID    string
+	Title string
+}
+
+// commandCategories lists the /ajuda sections in display order
+var commandCategories = []commandCategory{
+	{ID: "basico", Title: "🎯 *BÁSICOS* (PRA NÃO FICAR PERDIDO)"},
+	{ID: "acao", Title: "💪 *AÇÕES* (PRA GANHAR A VIDA)"},
+	{ID: "zona", Title: "🏃‍♂️ *ZONAS E LOCOMOÇÃO* (PRA NÃO FICAR PARADO)"},
+	{ID: "jogadores", Title: "🤝 *OUTROS JOGADORES* (PRA NÃO FICAR SOZINHO)"},
+	{ID: "faccao", Title: "🏴 *FACÇÕES* (PRA TER COM QUEM CONTAR)"},
+	{ID: "grupo", Title: "👥 *MISSÕES EM GRUPO* (PRA FAZER O CORRE JUNTO)"},
+	{ID: "evento", Title: "🎭 *EVENTOS* (PRA NÃO FICAR ENTEDIADO)"},
+}
+
+// CommandRegistry holds the fixed commands of the bot. Action commands are
+// generated from the loaded action catalog on every lookup, so new actions
+// become commands without code changes.
+type CommandRegistry struct {
+	commands []*Command
+	index    map[string]*Command
+	actions  func() []*types.Action
+	onAction func(ctx *CommandContext, action *types.Action) string
+}
+
+// NewCommandRegistry creates a registry whose action commands come from actions
+// and are run by onAction
+func NewCommandRegistry(actions func() []*types.Action, onAction func(ctx *CommandContext, action *types.Action) string) *CommandRegistry {
+	return &CommandRegistry{
+		index:    make(map[string]*Command),
+		actions:  actions,
+		onAction: onAction,
+	}
+}
+
+// Register adds a command under its name and aliases
+func (r *CommandRegistry) Register(command *Command) {
+	r.commands = append(r.commands, command)
+	r.index[command.Name] = command
+	for _, alias := range command.Aliases {
+		r.index[alias] = command
+	}
+}
+
+// Find returns the command invoked by name, or nil if there is none
+func (r *CommandRegistry) Find(name string) *Command {
+	if command, exists := r.index[name]; exists {
+		return command
+	}
+
+	for _, command := range r.actionCommands() {
+		if command.Name == name {
+			return command
+		}
+	}
+
+	for _, command := range r.commands {
+		if command.Match != nil && command.Match(name) {
+			return command
+		}
+	}
+
+	return nil
+}
+
+// actionCommands builds one command per action in the catalog
+func (r *CommandRegistry) actionCommands() []*Command {
+	if r.actions == nil {
+		return nil
+	}
+
+	actions := r.actions()
+	commands := make([]*Command, 0, len(actions))
+	for _, action := range actions {
+		// Fixed commands win over actions with the same name
+		if _, taken := r.index[action.Name]; taken {
+			continue
+		}
+
+		action := action
+		commands = append(commands, &Command{
+			Name:     action.Name,
+			Category: "acao",
+			Help:     action.Description,
+			Handler: func(ctx *CommandContext) string {
+				return r.onAction(ctx, action)
+			},
+		})
+	}
+
+	return commands
+}
+
+// Commands returns the fixed commands followed by the action commands
+func (r *CommandRegistry) Commands() []*Command {
+	return append(append([]*Command{}, r.commands...), r.actionCommands()...)
+}
+
+// Help renders the /ajuda message from the registered commands
+func (r *CommandRegistry) Help() string {
+	commands := r.Commands()
+
+	var help strings.Builder
+	help.WriteString("🎮 *VIDA LOKA STRATEGIA* - SEU GUIA DE SOBREVIVÊNCIA 🎮\n\n")
+
+	for _, category := range commandCategories {
+		var lines []string
+		for _, command := range commands {
+			if command.Hidden || command.Category != category.ID {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("%s - %s", command.usage(), command.Help))
+		}
+
+		if len(lines) == 0 {
+			continue
+		}
+
+		help.WriteString(category.Title + ":\n")
+		help.WriteString(strings.Join(lines, "\n"))
+		help.WriteString("\n\n")
+	}
+
+	help.WriteString("Boa sorte na sua jornada! Que a força esteja com você! 🍀✨")
+	return help.String()
+}
+
+// Dispatch parses a command line (without the slash) sent by sender in chat
+// (empty for private chats) and runs the matching command. It returns false
+// when no command matches.
+func (r *CommandRegistry) Dispatch(sender, chat, line string) (string, bool) {
+	name, rest, _ := strings.Cut(strings.TrimSpace(line), " ")
+
+	command := r.Find(name)
+	if command == nil {
+		return "", false
+	}
+
+	args, err := command.parseArgs(rest)
+	if err != nil {
+		return fmt.Sprintf("Ei, %s! 🧐\n\nUse: %s", err.Error(), command.usage()), true
+	}
+
+	return command.Handler(&CommandContext{
+		Sender: sender,
+		Chat:   chat,
+		Name:   name,
+		Args:   args,
+	}), true
+}
+
+// isEventOptionCommand reports whether name is a single option letter
+func isEventOptionCommand(name string) bool {
+	return len(name) == 1 && name[0] >= 'a' && name[0] <= 'z'
+}
+
+// registerCommands fills the registry with the bot's fixed commands
+func (cm *ClientManager) registerCommands() {
+	cm.commands = NewCommandRegistry(cm.gameManager.GetActions, func(ctx *CommandContext, action *types.Action) string {
+		return cm.handleActionCommand(ctx.Sender, action)
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "comecar",
+		Aliases:  []string{"começar", "iniciar"},
+		Args:     []CommandArg{{Name: "nome", Required: true, Rest: true}},
+		Category: "basico",
+		Help:     "Começa sua jornada de sucesso (ou fracasso) 🚀",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleRegistrationCommand(ctx.Sender, ctx.Arg(0))
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "personagens",
+		Category: "basico",
+		Help:     "Conheça os malucos que você pode ser 🎭",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleCharactersListCommand()
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "escolher",
+		Args:     []CommandArg{{Name: "número", Required: true}},
+		Category: "basico",
+		Help:     "Escolha seu personagem (escolha sabiamente) 🤔",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleCharacterSelectionCommand(ctx.Sender, ctx.Arg(0))
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "status",
+		Category: "basico",
+		Help:     "Veja como tá sua vida (ou o que sobrou dela) 📊",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleStatusCommand(ctx.Sender)
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "evoluir",
+		Args:     []CommandArg{{Name: "atributo"}, {Name: "pontos"}},
+		Category: "basico",
+		Help:     "Gaste seus pontos de atributo (fica mais forte, campeão) 💪",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleEvolveCommand(ctx.Sender, ctx.Arg(0), ctx.Arg(1))
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "evolucoes",
+		Aliases:  []string{"evoluções"},
+		Category: "basico",
+		Help:     "Veja quanto falta pra você virar outra pessoa 🦋",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleEvolutionsCommand(ctx.Sender)
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "piloto",
+		Category: "basico",
+		Help:     "Liga/desliga o piloto automático (o bot joga por você) 🤖",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleAutoPilotCommand(ctx.Sender)
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "notificacoes",
+		Aliases:  []string{"notificações"},
+		Args:     []CommandArg{{Name: "opção"}, {Name: "valor", Rest: true}},
+		Category: "basico",
+		Help:     "Escolha quando e o que o jogo te manda (pra dormir em paz) 🔔",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleNotificationsCommand(ctx.Sender, ctx.Arg(0), ctx.Arg(1))
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "ajuda",
+		Aliases:  []string{"help"},
+		Category: "basico",
+		Help:     "Tá perdido? Chama o tio aqui! 🆘",
+		Handler: func(ctx *CommandContext) string {
+			return cm.commands.Help()
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "mover",
+		Args:     []CommandArg{{Name: "subzona", Rest: true}},
+		Category: "zona",
+		Help:     "Veja quanto custa ir pra outro lugar (antes que te peguem) 🏃‍♂️",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleMoveCommand(ctx.Sender, ctx.Arg(0))
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "confirmar",
+		Category: "zona",
+		Help:     "Confirma a viagem e cai na estrada 🚌",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleConfirmTravelCommand(ctx.Sender)
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "desafiar",
+		Args:     []CommandArg{{Name: "@nome", Required: true, Rest: true}},
+		Category: "jogadores",
+		Help:     "Desafia alguém do seu lado pra ver quem é o mais brabo ⚔️",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleInteractionCommand(ctx.Sender, "desafiar", ctx.Arg(0))
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "roubar",
+		Args:     []CommandArg{{Name: "@nome", Required: true, Rest: true}},
+		Category: "jogadores",
+		Help:     "Tenta roubar alguém do seu lado (sem pedir licença) 🦹",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleInteractionCommand(ctx.Sender, "roubar", ctx.Arg(0))
+		},
+	})
+
+	// Without a name /ajudar is still the volunteering action
+	cm.commands.Register(&Command{
+		Name:     "ajudar",
+		Args:     []CommandArg{{Name: "@nome", Rest: true}},
+		Category: "jogadores",
+		Help:     "Dá uma força pra alguém do seu lado (sem nome, ajuda a comunidade) 🤝",
+		Handler: func(ctx *CommandContext) string {
+			if ctx.Arg(0) == "" {
+				for _, action := range cm.gameManager.GetActions() {
+					if action.Name == "ajudar" {
+						return cm.handleActionCommand(ctx.Sender, action)
+					}
+				}
+				return "Ei, faltou dizer quem! 🧐\n\nUse: */ajudar [@nome]*"
+			}
+			return cm.handleInteractionCommand(ctx.Sender, "ajudar", ctx.Arg(0))
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "pagar",
+		Args:     []CommandArg{{Name: "@nome", Required: true, Rest: true}},
+		Usage:    "*/pagar [@nome] [valor]*",
+		Category: "jogadores",
+		Help:     "Paga alguém, esteja onde estiver 💸",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleInteractionCommand(ctx.Sender, "pagar", ctx.Arg(0))
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "aceitar",
+		Category: "jogadores",
+		Help:     "Aceita o desafio, a ajuda ou o pagamento que te ofereceram ✅",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleInteractionResponseCommand(ctx.Sender, true)
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "recusar",
+		Category: "jogadores",
+		Help:     "Recusa o convite (ninguém é obrigado) ❌",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleInteractionResponseCommand(ctx.Sender, false)
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "faccao",
+		Aliases:  []string{"facção"},
+		Category: "faccao",
+		Help:     "Veja sua facção: membros, caixa e território 🏴",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleFactionCommand(ctx.Sender)
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "fundar",
+		Args:     []CommandArg{{Name: "nome", Required: true, Rest: true}},
+		Category: "faccao",
+		Help:     "Funda sua própria facção (e vira o líder) 👑",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleCreateFactionCommand(ctx.Sender, ctx.Arg(0))
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "convidar",
+		Args:     []CommandArg{{Name: "@nome", Required: true, Rest: true}},
+		Category: "faccao",
+		Help:     "Chama alguém pra sua facção (só o líder) 📨",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleInviteToFactionCommand(ctx.Sender, ctx.Arg(0))
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "entrar",
+		Args:     []CommandArg{{Name: "facção", Required: true, Rest: true}},
+		Category: "faccao",
+		Help:     "Entra na facção que te convidou 🤝",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleJoinFactionCommand(ctx.Sender, ctx.Arg(0))
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "sair",
+		Category: "faccao",
+		Help:     "Sai da sua facção 🚪",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleLeaveFactionCommand(ctx.Sender)
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "expulsar",
+		Args:     []CommandArg{{Name: "@nome", Required: true, Rest: true}},
+		Category: "faccao",
+		Help:     "Tira alguém da sua facção (só o líder) 🥾",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleKickFromFactionCommand(ctx.Sender, ctx.Arg(0))
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "contribuir",
+		Args:     []CommandArg{{Name: "valor", Required: true}},
+		Category: "faccao",
+		Help:     "Coloca dinheiro no caixa da facção 💰",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleContributeCommand(ctx.Sender, ctx.Arg(0))
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "dominar",
+		Category: "faccao",
+		Help:     "Toma o lugar onde você está pra sua facção, com o dinheiro do caixa (só o líder) 🚩",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleClaimTerritoryCommand(ctx.Sender)
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "missao",
+		Aliases:  []string{"missão"},
+		Args:     []CommandArg{{Name: "nome", Rest: true}},
+		Category: "grupo",
+		Help:     "Abre uma missão no grupo, ou mostra a que está rolando 🎯",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleMissionCommand(ctx.Sender, ctx.Chat, ctx.Arg(0))
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "participar",
+		Category: "grupo",
+		Help:     "Entra na missão aberta no grupo 🙋",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleJoinMissionCommand(ctx.Sender, ctx.Chat)
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "partiu",
+		Category: "grupo",
+		Help:     "Começa a missão sem esperar mais ninguém (só quem abriu) 🚀",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleLaunchMissionCommand(ctx.Sender, ctx.Chat)
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "votar",
+		Args:     []CommandArg{{Name: "letra", Required: true}},
+		Category: "grupo",
+		Help:     "Vota no que a equipe faz nessa etapa (todo mundo que votar rola junto) 🗳️",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleVoteMissionCommand(ctx.Sender, ctx.Chat, ctx.Arg(0))
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "trair",
+		Category: "grupo",
+		Help:     "Tenta fugir com metade do pote da missão (se te pegarem, já era) 🐍",
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleBetrayMissionCommand(ctx.Sender, ctx.Chat)
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:     "a",
+		Usage:    "*/a*, */b*, */c*...",
+		Category: "evento",
+		Help:     "Responde ao evento com a letra da opção (sucesso = 1d20 + atributo) 🎲",
+		Match:    isEventOptionCommand,
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleEventResponseCommand(ctx.Sender, ctx.Name)
+		},
+	})
+
+	cm.commands.Register(&Command{
+		Name:   "setup",
+		Hidden: true,
+		Handler: func(ctx *CommandContext) string {
+			return cm.handleSetupCommand(ctx.Sender)
+		},
+	})
+}
@@ -255,7 +255,7 @@ func (mf *MessageFormatter) FormatEventMessage(event *EventMessage) string {
 	if len(event.Options) > 0 {
 		message += "Escolha sua ação:\n"
 		for i, option := range event.Options {
-			message += fmt.Sprintf("%s. %s\n", string('A'+i), option)
+			message += fmt.Sprintf("%s. %s\n", string(rune('A'+i)), option)
 		}
 	}
 
@@ -267,7 +267,7 @@ func (mf *MessageFormatter) FormatStatusMessage(status *StatusMessage) string {
 	message := fmt.Sprintf("📊 STATUS DE %s 📊\n\n", status.PlayerName)
 	message += fmt.Sprintf("Personagem: %s (%s)\n", status.CharacterName, status.CharacterType)
 	message += fmt.Sprintf("XP: %d\n", status.XP)
-	message += fmt.Sprintf("Dinheiro: R$ %.2f\n", status.Money)
+	message += fmt.Sprintf("Dinheiro: R$ %d,00\n", status.Money)
 	message += fmt.Sprintf("Influência: %d\n", status.Influence)
 	message += fmt.Sprintf("Estresse: %d/100\n", status.Stress)
 	message += fmt.Sprintf("Localização: %s\n\n", status.Location)